@@ -0,0 +1,205 @@
+package cache
+
+import (
+	// stdlib
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+)
+
+const (
+	// DefaultTTL is a default cached data lifetime.
+	DefaultTTL = time.Hour * 24 * 7
+	// FormatVersion is a cached data format version. It should be
+	// increased every time cached data structures change, so entries
+	// written by previous glp versions aren't used.
+	FormatVersion = 2
+)
+
+var (
+	cacheDir string
+	ttl      time.Duration
+	disabled bool
+)
+
+// Entry is a single cached entry.
+type Entry struct {
+	// Bucket is a cached data kind (e.g. "godata").
+	Bucket string `json:"bucket"`
+	// Key is an entry key within bucket.
+	Key string `json:"key"`
+	// Created is an entry creation timestamp.
+	Created time.Time `json:"created"`
+	// Data is a cached data.
+	Data json.RawMessage `json:"data"`
+	// Version is a cached data format version, see FormatVersion.
+	Version int `json:"version"`
+}
+
+// Initialize initializes package. Empty directory means default cache
+// directory in user's cache directory, zero TTL means DefaultTTL.
+func Initialize(dir string, dataTTL time.Duration, disable bool) {
+	logger.Debug("Initializing cache...")
+
+	cacheDir = dir
+	if cacheDir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			logger.Warn("Failed to get user's cache directory, cache will be disabled:", err.Error())
+
+			disable = true
+		}
+
+		cacheDir = filepath.Join(userCacheDir, "glp")
+	}
+
+	ttl = dataTTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+
+	disabled = disable
+
+	logger.Debug("Cache directory:", cacheDir)
+}
+
+// Path returns cache directory path.
+func Path() string {
+	return cacheDir
+}
+
+// Get reads cached data into passed value. It returns false if data
+// wasn't found, expired or cannot be read.
+func Get(bucket string, key string, value interface{}) bool {
+	if disabled {
+		return false
+	}
+
+	data, err := ioutil.ReadFile(entryPath(bucket, key))
+	if err != nil {
+		return false
+	}
+
+	entry := &Entry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		logger.Debug("Failed to parse cache entry for '"+bucket+"/"+key+"':", err.Error())
+		return false
+	}
+
+	if entry.Key != key || entry.IsExpired() {
+		return false
+	}
+
+	if err := json.Unmarshal(entry.Data, value); err != nil {
+		logger.Debug("Failed to parse cached data for '"+bucket+"/"+key+"':", err.Error())
+		return false
+	}
+
+	logger.Trace("Got cached data for '" + bucket + "/" + key + "'")
+
+	return true
+}
+
+// Set writes passed value into cache. Errors are logged and otherwise
+// ignored as cache is optional.
+func Set(bucket string, key string, value interface{}) {
+	if disabled {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.Debug("Failed to prepare data for caching:", err.Error())
+		return
+	}
+
+	entryData, err1 := json.Marshal(&Entry{Bucket: bucket, Key: key, Created: time.Now(), Data: data, Version: FormatVersion})
+	if err1 != nil {
+		logger.Debug("Failed to prepare cache entry:", err1.Error())
+		return
+	}
+
+	path := entryPath(bucket, key)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logger.Warn("Failed to create cache directory:", err.Error())
+		return
+	}
+
+	if err := ioutil.WriteFile(path, entryData, 0644); err != nil {
+		logger.Warn("Failed to write cache entry:", err.Error())
+	}
+}
+
+// Entries returns all cached entries sorted by bucket and key.
+func Entries() ([]*Entry, error) {
+	var entries []*Entry
+
+	err := filepath.Walk(cacheDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if info.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		data, err1 := ioutil.ReadFile(path)
+		if err1 != nil {
+			return err1
+		}
+
+		entry := &Entry{}
+		if err := json.Unmarshal(data, entry); err != nil {
+			logger.Warn("Failed to parse cache entry '"+path+"':", err.Error())
+			return nil
+		}
+
+		entries = append(entries, entry)
+
+		return nil
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Bucket == entries[j].Bucket {
+			return entries[i].Key < entries[j].Key
+		}
+
+		return entries[i].Bucket < entries[j].Bucket
+	})
+
+	return entries, err
+}
+
+// IsExpired returns true if entry is older than configured TTL or was
+// written in other format version.
+func (e *Entry) IsExpired() bool {
+	return e.Version != FormatVersion || time.Since(e.Created) > ttl
+}
+
+// Clear removes all cached data.
+func Clear() error {
+	logger.Info("Removing cache directory '" + cacheDir + "'...")
+
+	return os.RemoveAll(cacheDir)
+}
+
+// Returns path to file for cache entry.
+func entryPath(bucket string, key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(cacheDir, bucket, hex.EncodeToString(hash[:])+".json")
+}
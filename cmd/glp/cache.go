@@ -0,0 +1,73 @@
+package main
+
+import (
+	// stdlib
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	// local
+	"go.dev.pztrn.name/glp/cache"
+	"go.dev.pztrn.name/glp/logger"
+)
+
+// Inspects or clears cache.
+func runCache(args []string) int {
+	var cf commonFlags
+
+	fs := newFlagSet("cache", " inspect|clear", "Inspects (lists) or clears cached data, like go-import and go-source data for dependencies.",
+		"  0 - success\n  1 - error appeared\n  2 - invalid parameters")
+	cf.register(fs)
+
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if fs.NArg() != 1 || (fs.Arg(0) != "inspect" && fs.Arg(0) != "clear") {
+		fmt.Fprintln(fs.Output(), "Action should be either 'inspect' or 'clear'.")
+		fs.Usage()
+
+		return exitCodeUsage
+	}
+
+	if ok, code := cf.initialize(fs); !ok {
+		return code
+	}
+
+	if fs.Arg(0) == "clear" {
+		if err := cache.Clear(); err != nil {
+			logger.Error("Failed to clear cache:", err.Error())
+			return exitCodeError
+		}
+
+		fmt.Println("Cache cleared.")
+
+		return exitCodeOK
+	}
+
+	entries, err := cache.Entries()
+	if err != nil {
+		logger.Error("Failed to read cache:", err.Error())
+		return exitCodeError
+	}
+
+	fmt.Println("Cache directory:", cache.Path())
+	fmt.Println("Entries:", len(entries))
+
+	if len(entries) == 0 {
+		return exitCodeOK
+	}
+
+	fmt.Println()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BUCKET\tKEY\tCREATED\tEXPIRED")
+
+	for _, entry := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\n", entry.Bucket, entry.Key, entry.Created.Format("2006-01-02 15:04:05"), entry.IsExpired())
+	}
+
+	_ = tw.Flush()
+
+	return exitCodeOK
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	// stdlib
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/policy"
+	"go.dev.pztrn.name/glp/projecter"
+)
+
+// Scans projects and checks dependencies against licensing policy.
+func runCheck(args []string) int {
+	var cf commonFlags
+
+	fs := newFlagSet("check", "", "Scans projects and checks dependencies licenses against policy from configuration file. No report is written.",
+		"  0 - policy isn't violated\n  1 - error appeared\n  2 - invalid parameters\n  3 - policy is violated")
+	cf.registerScan(fs)
+
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if ok, code := cf.initializeScan(fs); !ok {
+		return code
+	}
+
+	deps := projecter.Parse()
+	diagnostics.Report(deps)
+
+	violations := policy.Check(deps)
+	if len(violations) == 0 {
+		fmt.Println("No licensing policy violations found.")
+		return exitCodeOK
+	}
+
+	fmt.Println()
+	fmt.Printf("Found %d licensing policy violations:\n\n", len(violations))
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODULE\tVERSION\tPROJECT\tREASON")
+
+	for _, violation := range violations {
+		dep := violation.Dependency
+		fmt.Fprintln(tw, dep.Name+"\t"+dep.Version+"\t"+dep.Parent+"\t"+violation.Reason)
+	}
+
+	_ = tw.Flush()
+
+	return exitCodeCheckFailed
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	// stdlib
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	// local
+	"go.dev.pztrn.name/glp/cache"
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/golist"
+	"go.dev.pztrn.name/glp/httpclient"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters"
+	"go.dev.pztrn.name/glp/parsers"
+	"go.dev.pztrn.name/glp/projecter"
+	"go.dev.pztrn.name/glp/workers"
+)
+
+// This structure holds parameters shared by all commands.
+type commonFlags struct {
+	configurationPath string
+	logFormat         string
+	logLevel          string
+	quiet             bool
+
+	// Following parameters are only for commands that are scanning
+	// projects.
+	aggregation     string
+	diagnosticsFile string
+	jobs            int
+	packagesPaths   string
+
+	// Following parameters restricts report to modules linked into
+	// binaries.
+	linkedOnly bool
+	mains      string
+	platforms  string
+	tags       string
+}
+
+// Creates flag set for command with usage message that describes
+// command and it's exit codes.
+func newFlagSet(name string, arguments string, description string, exitCodes string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: glp "+name+" [parameters]"+arguments)
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), description)
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Parameters:")
+		fs.PrintDefaults()
+		fmt.Fprintln(fs.Output())
+		fmt.Fprintln(fs.Output(), "Exit codes:")
+		fmt.Fprintln(fs.Output(), exitCodes)
+	}
+
+	return fs
+}
+
+// Parses command line parameters. Returns false and exit code if
+// command should not continue.
+func parseFlags(fs *flag.FlagSet, args []string) (bool, int) {
+	err := fs.Parse(args)
+	if err == flag.ErrHelp {
+		return false, exitCodeOK
+	}
+
+	if err != nil {
+		return false, exitCodeUsage
+	}
+
+	return true, exitCodeOK
+}
+
+// Registers parameters shared by all commands.
+func (cf *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&cf.configurationPath, "config", "./.glp.yaml", "Path to configuration file.")
+	fs.StringVar(&cf.logLevel, "log-level", "", "Logging level: error, warn, info, debug or trace. Overrides configuration file value. Default is 'info'.")
+	fs.StringVar(&cf.logFormat, "log-format", "", "Log lines format: text or json. Overrides configuration file value. Default is 'text'.")
+	fs.BoolVar(&cf.quiet, "quiet", false, "Do not write any log lines, only final result.")
+}
+
+// Registers parameters shared by commands that are scanning projects.
+func (cf *commonFlags) registerScan(fs *flag.FlagSet) {
+	cf.register(fs)
+
+	fs.StringVar(&cf.packagesPaths, "pkgs", "", "Packages that should be analyzed. Use comma to delimit packages.")
+	fs.StringVar(&cf.aggregation, "aggregate", "", "Merge same dependencies from different projects into one report row: 'none', 'version' (same name and version) or 'module' (same name, all versions listed). Overrides configuration file value. Default is 'none'.")
+	fs.StringVar(&cf.diagnosticsFile, "diagnostics-file", "", "File to write machine-readable (JSON) diagnostics and run summary to. Optional.")
+	fs.BoolVar(&cf.linkedOnly, "linked-only", false, "Report only modules providing packages compiled into binaries, excluding test-only and tools dependencies. Go modules projects only. Implied by '-mains', '-platforms' and '-tags'.")
+	fs.StringVar(&cf.mains, "mains", "", "Main packages binaries are built from, relative to project's directory, e.g. './cmd/app'. Use comma to delimit packages. Overrides configuration file value. Default is './...'.")
+	fs.StringVar(&cf.platforms, "platforms", "", "Platforms binaries are built for as 'GOOS/GOARCH' pairs, e.g. 'linux/amd64,windows/amd64'. Overrides configuration file value. Default is current platform.")
+	fs.StringVar(&cf.tags, "tags", "", "Build tags binaries are built with. Use comma to delimit tags. Overrides configuration file value.")
+	fs.IntVar(&cf.jobs, "jobs", 0, "Maximum number of simultaneously executed network requests and license scans. Overrides configuration file value. Default is number of CPUs.")
+}
+
+// Loads configuration and configures logger and cache. Returns false
+// and exit code if command should not continue: invalid command line
+// parameters are usage errors, while invalid configuration is an
+// error.
+func (cf *commonFlags) initialize(fs *flag.FlagSet) (bool, int) {
+	// Configuration file isn't loaded yet, so use only command line
+	// parameters for now.
+	if !cf.configureLogger(fs, "", "") {
+		fs.Usage()
+		return false, exitCodeUsage
+	}
+
+	logger.Info("Starting glp")
+
+	configuration.Initialize(cf.configurationPath)
+
+	cfgLogLevel := configuration.Cfg.Log.Level
+	if cfgLogLevel == "" && configuration.Cfg.Log.Debug {
+		cfgLogLevel = "debug"
+	}
+
+	// Command line parameters are already checked, so failure here is
+	// caused by configuration file.
+	if !cf.configureLogger(fs, cfgLogLevel, configuration.Cfg.Log.Format) {
+		return false, exitCodeError
+	}
+
+	var cacheTTL time.Duration
+
+	if configuration.Cfg.Cache.TTL != "" {
+		var err error
+
+		cacheTTL, err = time.ParseDuration(configuration.Cfg.Cache.TTL)
+		if err != nil {
+			logger.Error("Invalid cache TTL in configuration:", err.Error())
+			return false, exitCodeError
+		}
+	}
+
+	cache.Initialize(configuration.Cfg.Cache.Path, cacheTTL, configuration.Cfg.Cache.Disabled)
+
+	return true, exitCodeOK
+}
+
+// Validates scanning parameters and initializes everything that is
+// needed for projects scanning. Returns false and exit code if command
+// should not continue.
+func (cf *commonFlags) initializeScan(fs *flag.FlagSet) (bool, int) {
+	if cf.packagesPaths == "" {
+		fmt.Fprintln(fs.Output(), "Packages paths that should be analyzed should be defined.")
+		fs.Usage()
+
+		return false, exitCodeUsage
+	}
+
+	if ok, code := cf.initialize(fs); !ok {
+		return false, code
+	}
+
+	// Mode passed with command line parameter is a usage error, while
+	// mode taken from configuration file is an error.
+	invalidModeCode := exitCodeUsage
+
+	if cf.aggregation == "" {
+		cf.aggregation = configuration.Cfg.Aggregate
+		invalidModeCode = exitCodeError
+	}
+
+	if cf.aggregation == "" {
+		cf.aggregation = projecter.AggregateNone
+	}
+
+	if err := projecter.CheckAggregationMode(cf.aggregation); err != nil {
+		logger.Error(err.Error())
+		return false, invalidModeCode
+	}
+
+	if cf.jobs == 0 {
+		cf.jobs = configuration.Cfg.Jobs
+	}
+
+	if ok, code := cf.configureGolang(); !ok {
+		return false, code
+	}
+
+	diagnostics.Initialize(cf.diagnosticsFile)
+	workers.Initialize(cf.jobs)
+	parsers.Initialize()
+	outputters.Initialize()
+	httpclient.Initialize()
+
+	projecter.Initialize(cf.packagesPaths, cf.aggregation)
+
+	return true, exitCodeOK
+}
+
+// Applies Go build parameters to configuration. Command line
+// parameters have priority over configuration file values. Returns
+// false and exit code if platforms are invalid.
+func (cf *commonFlags) configureGolang() (bool, int) {
+	cfg := &configuration.Cfg.Golang
+
+	if cf.linkedOnly {
+		cfg.LinkedOnly = true
+	}
+
+	if cf.mains != "" {
+		cfg.Mains = strings.Split(cf.mains, ",")
+	}
+
+	// Platforms passed with command line parameter are a usage error,
+	// while platforms taken from configuration file are an error.
+	invalidPlatformCode := exitCodeError
+
+	if cf.platforms != "" {
+		cfg.Platforms = strings.Split(cf.platforms, ",")
+		invalidPlatformCode = exitCodeUsage
+	}
+
+	if cf.tags != "" {
+		cfg.Tags = strings.Split(cf.tags, ",")
+	}
+
+	for _, platform := range cfg.Platforms {
+		if err := golist.CheckPlatform(platform); err != nil {
+			logger.Error(err.Error())
+			return false, invalidPlatformCode
+		}
+	}
+
+	return true, exitCodeOK
+}
+
+// Configures logger. Command line parameters have priority over passed
+// values which are taken from configuration file.
+func (cf *commonFlags) configureLogger(fs *flag.FlagSet, cfgLevel string, cfgFormat string) bool {
+	level := "info"
+	if cf.logLevel != "" {
+		level = cf.logLevel
+	} else if cfgLevel != "" {
+		level = cfgLevel
+	}
+
+	format := logger.FormatText
+	if cf.logFormat != "" {
+		format = cf.logFormat
+	} else if cfgFormat != "" {
+		format = cfgFormat
+	}
+
+	err := logger.Configure(level, format, cf.quiet)
+	if err != nil {
+		fmt.Fprintln(fs.Output(), "Failed to configure logging:", err.Error())
+		return false
+	}
+
+	return true
+}
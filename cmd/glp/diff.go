@@ -0,0 +1,105 @@
+package main
+
+import (
+	// stdlib
+	"fmt"
+	"io"
+	"os"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/differ"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/projecter"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Compares reports or scan result with baseline report.
+func runDiff(args []string) int {
+	var (
+		cf           commonFlags
+		baselineFile string
+		currentFile  string
+	)
+
+	fs := newFlagSet("diff", "", "Compares current report (or scan result if -pkgs is passed) with baseline report and prints changes in Markdown.",
+		"  0 - no license changes found\n  1 - error appeared\n  2 - invalid parameters\n  3 - license of some dependency changed or license not present in baseline appeared")
+	cf.registerScan(fs)
+	fs.StringVar(&baselineFile, "baseline", "", "Previously written CSV report to compare with.")
+	fs.StringVar(&currentFile, "current", "", "CSV report to compare with baseline. If not passed - projects from -pkgs will be scanned.")
+
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if baselineFile == "" {
+		fmt.Fprintln(fs.Output(), "Baseline report path should be defined.")
+		fs.Usage()
+
+		return exitCodeUsage
+	}
+
+	if currentFile == "" && cf.packagesPaths == "" {
+		fmt.Fprintln(fs.Output(), "Either current report path or packages paths should be defined.")
+		fs.Usage()
+
+		return exitCodeUsage
+	}
+
+	// Comparing two reports requires nothing but logging. Configuration
+	// file is optional, it is needed to read reports written with
+	// custom CSV settings.
+	if currentFile != "" {
+		if !cf.configureLogger(fs, "", "") {
+			fs.Usage()
+			return exitCodeUsage
+		}
+
+		if _, err := os.Stat(cf.configurationPath); err == nil {
+			configuration.Initialize(cf.configurationPath)
+		} else {
+			configuration.InitializeDefaults()
+		}
+
+		result, err := differ.CompareReports(baselineFile, currentFile)
+		if err != nil {
+			logger.Error("Failed to compare reports:", err.Error())
+			return exitCodeError
+		}
+
+		return printDiffResult(result, os.Stdout)
+	}
+
+	if ok, code := cf.initializeScan(fs); !ok {
+		return code
+	}
+
+	deps := projecter.Parse()
+	diagnostics.Report(deps)
+
+	return compareWithBaseline(baselineFile, deps, os.Stdout)
+}
+
+// Compares dependencies with baseline report, prints result into
+// passed writer and returns exit code.
+func compareWithBaseline(baselineFile string, deps []*structs.Dependency, w io.Writer) int {
+	result, err := differ.Compare(baselineFile, deps)
+	if err != nil {
+		logger.Error("Failed to compare with baseline report:", err.Error())
+		return exitCodeError
+	}
+
+	return printDiffResult(result, w)
+}
+
+// Prints comparison result and returns exit code.
+func printDiffResult(result *differ.Result, w io.Writer) int {
+	result.Print(w)
+
+	if result.Failed() {
+		return exitCodeCheckFailed
+	}
+
+	return exitCodeOK
+}
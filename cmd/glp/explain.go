@@ -0,0 +1,130 @@
+package main
+
+import (
+	// stdlib
+	"fmt"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/projecter"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Shows how license was determined for module.
+func runExplain(args []string) int {
+	var cf commonFlags
+
+	fs := newFlagSet("explain", " <module>", "Scans projects and shows how license was determined for passed module: all matched licenses with their confidences and files, VCS data and resulting report data.",
+		"  0 - module was found\n  1 - error appeared\n  2 - invalid parameters\n  4 - module wasn't found in projects dependencies")
+	cf.registerScan(fs)
+
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(fs.Output(), "Exactly one module name should be passed.")
+		fs.Usage()
+
+		return exitCodeUsage
+	}
+
+	if ok, code := cf.initializeScan(fs); !ok {
+		return code
+	}
+
+	module := fs.Arg(0)
+	found := false
+
+	for _, dep := range projecter.Parse() {
+		if dep.Name != module {
+			continue
+		}
+
+		found = true
+
+		printExplanation(dep)
+	}
+
+	if !found {
+		fmt.Println("Module '" + module + "' wasn't found in projects dependencies.")
+		return exitCodeNotFound
+	}
+
+	return exitCodeOK
+}
+
+// Prints explanation: dependency's data and all licenses matched while
+// scanning.
+func printExplanation(dep *structs.Dependency) {
+	fmt.Println(dep.Name + "@" + dep.Version)
+	printField("Project", dep.Parent)
+	printField("Local path", dep.LocalPath)
+
+	if dep.RepositoryPath != "" {
+		printField("Repository path", dep.RepositoryPath)
+	}
+
+	printField("VCS", dep.VCS.VCS)
+	printField("Repository", dep.VCS.VCSPath)
+	printField("Source template", dep.VCS.SourceURLFileTemplate)
+	printField("Checksum", dep.Checksum+" (verification: "+dep.Verification+")")
+	fmt.Println("  License matches (license detector, most confident first):")
+
+	if len(dep.License.Candidates) == 0 {
+		fmt.Println("    none")
+	}
+
+	for _, candidate := range dep.License.Candidates {
+		fmt.Printf("    %-20s confidence %.2f, file: %s\n", candidate.Name, candidate.Confidence, candidate.File)
+	}
+
+	printField("Expression", dep.License.Expression)
+	printField("Concluded license", dep.License.Name)
+	printField("Detection method", dep.License.DetectionMethod)
+	printField("License URL", dep.License.URL)
+	printField("NOTICE file", dep.License.NoticeFile)
+
+	if len(dep.License.Nested) > 0 {
+		fmt.Println("  Nested licenses:")
+
+		for _, nested := range dep.License.Nested {
+			usage := nested.Usage
+			if usage == "" {
+				usage = "usage unknown"
+			}
+
+			fmt.Printf("    %s: %s (%s, %s, confidence %.2f)\n", nested.Path, nested.Expression, nested.File, usage, nested.Confidence)
+		}
+	}
+
+	fmt.Println("  Copyrights:")
+
+	for _, copyright := range dep.License.Copyrights {
+		fmt.Println("    " + copyright)
+	}
+
+	// Problems explain why some data is missing.
+	problems := make([]*diagnostics.Entry, 0)
+
+	for _, entry := range diagnostics.Entries() {
+		if entry.Subject == dep.Name+"@"+dep.Version {
+			problems = append(problems, entry)
+		}
+	}
+
+	if len(problems) > 0 {
+		fmt.Println("  Problems:")
+
+		for _, entry := range problems {
+			fmt.Println("    " + string(entry.Severity) + " " + string(entry.Code) + ": " + entry.Message)
+		}
+	}
+
+	fmt.Println()
+}
+
+// Prints explanation field with label aligned with other fields.
+func printField(label string, value string) {
+	fmt.Printf("  %-19s%s\n", label+":", value)
+}
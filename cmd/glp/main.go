@@ -2,52 +2,89 @@ package main
 
 import (
 	// stdlib
-	"flag"
-	"log"
+	"fmt"
 	"os"
-
-	// local
-	"go.dev.pztrn.name/glp/configuration"
-	"go.dev.pztrn.name/glp/httpclient"
-	"go.dev.pztrn.name/glp/outputters"
-	"go.dev.pztrn.name/glp/parsers"
-	"go.dev.pztrn.name/glp/projecter"
+	"strings"
 )
 
-var (
-	configurationPath string
-	packagesPaths     string
-	outputFormat      string
-	outputFile        string
+// Exit codes shared by all commands.
+const (
+	// Everything is fine.
+	exitCodeOK = 0
+	// Error appeared while doing work.
+	exitCodeError = 1
+	// Invalid command or parameters was passed.
+	exitCodeUsage = 2
+	// Check failed: policy violated or license changed comparing to
+	// baseline.
+	exitCodeCheckFailed = 3
+	// Requested thing (e.g. module to explain) wasn't found.
+	exitCodeNotFound = 4
 )
 
+// This structure describes single command.
+type command struct {
+	name        string
+	description string
+	run         func(args []string) int
+}
+
+var commands = []*command{
+	{name: "scan", description: "Scan projects and write report.", run: runScan},
+	{name: "check", description: "Scan projects and check dependencies licenses against policy.", run: runCheck},
+	{name: "diff", description: "Compare report or scan result with baseline report.", run: runDiff},
+	{name: "notice", description: "Scan projects and write third party notices (attribution) file.", run: runNotice},
+	{name: "cache", description: "Inspect or clear cached data.", run: runCache},
+	{name: "explain", description: "Show how license was determined for module.", run: runExplain},
+}
+
 func main() {
-	log.Println("Starting glp")
+	os.Exit(run(os.Args[1:]))
+}
 
-	flag.StringVar(&configurationPath, "config", "./.glp.yaml", "Path to configuration file.")
-	flag.StringVar(&packagesPaths, "pkgs", "", "Packages that should be analyzed. Use comma to delimit packages.")
-	flag.StringVar(&outputFormat, "outformat", "csv", "Output file format. Only 'csv' for now.")
-	flag.StringVar(&outputFile, "outfile", "", "File to write licensing information to.")
+// Chooses command and runs it.
+func run(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return exitCodeUsage
+	}
 
-	flag.Parse()
+	// glp was used without commands before, so parameters without
+	// command are passed to scan command.
+	if strings.HasPrefix(args[0], "-") && args[0] != "-h" && args[0] != "-help" && args[0] != "--help" {
+		fmt.Fprintln(os.Stderr, "WARNING: running glp without command is deprecated, use 'glp scan'.")
+		return runScan(args)
+	}
 
-	if packagesPaths == "" {
-		log.Println("Packages paths that should be analyzed should be defined.")
-		flag.PrintDefaults()
-		os.Exit(1)
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:])
+		}
 	}
 
-	if outputFile == "" {
-		log.Println("Output file path should be defined.")
-		flag.PrintDefaults()
-		os.Exit(1)
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		printUsage()
+		return exitCodeOK
 	}
 
-	configuration.Initialize(configurationPath)
-	parsers.Initialize()
-	outputters.Initialize()
-	httpclient.Initialize()
+	fmt.Fprintln(os.Stderr, "Unknown command '"+args[0]+"'.")
+	printUsage()
+
+	return exitCodeUsage
+}
+
+// Prints general usage information.
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "glp - Go Licensing Processor.")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Usage: glp <command> [parameters]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.description)
+	}
 
-	projecter.Initialize(packagesPaths, outputFormat, outputFile)
-	projecter.Parse()
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Use 'glp <command> -h' to get command help.")
 }
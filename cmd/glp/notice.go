@@ -0,0 +1,70 @@
+package main
+
+import (
+	// stdlib
+	"fmt"
+	"os"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters"
+	"go.dev.pztrn.name/glp/projecter"
+)
+
+// Scans projects and writes third party notices file.
+func runNotice(args []string) int {
+	var (
+		cf           commonFlags
+		outputFile   string
+		outputFormat string
+	)
+
+	fs := newFlagSet("notice", "", "Scans projects and writes third party notices (attribution) file.",
+		"  0 - notices file was written\n  1 - error appeared\n  2 - invalid parameters")
+	cf.registerScan(fs)
+	fs.StringVar(&outputFile, "outfile", "THIRD_PARTY_NOTICES", "File to write notices to. Use '-' to write to standard output.")
+	fs.StringVar(&outputFormat, "format", "text", "Notices file format: 'text' or 'markdown'.")
+
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if outputFile == "" {
+		fmt.Fprintln(fs.Output(), "Output file path should be defined.")
+		fs.Usage()
+
+		return exitCodeUsage
+	}
+
+	outputter := "notice"
+
+	switch outputFormat {
+	case "text":
+	case "markdown":
+		outputter = "notice-markdown"
+	default:
+		fmt.Fprintln(fs.Output(), "Unknown notices file format '"+outputFormat+"'.")
+		fs.Usage()
+
+		return exitCodeUsage
+	}
+
+	if ok, code := cf.initializeScan(fs); !ok {
+		return code
+	}
+
+	if outputFile == outputters.StdoutPath {
+		diagnostics.SetOutput(os.Stderr)
+	}
+
+	deps := projecter.Parse()
+
+	if err := outputters.Write(outputter, outputFile, deps); err != nil {
+		logger.Error("Failed to write report:", err.Error())
+		return exitCodeError
+	}
+	diagnostics.Report(deps)
+
+	return exitCodeOK
+}
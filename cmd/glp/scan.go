@@ -0,0 +1,141 @@
+package main
+
+import (
+	// stdlib
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters"
+	"go.dev.pztrn.name/glp/outputters/template"
+	"go.dev.pztrn.name/glp/projecter"
+)
+
+// This type holds reports defined with repeatable "-output" parameter.
+type outputsFlag []*outputters.Output
+
+func (of *outputsFlag) String() string {
+	values := make([]string, 0, len(*of))
+	for _, output := range *of {
+		values = append(values, output.Format+":"+output.Path)
+	}
+
+	return strings.Join(values, ",")
+}
+
+func (of *outputsFlag) Set(value string) error {
+	for _, pair := range strings.Split(value, ",") {
+		output, err := outputters.ParseOutput(pair)
+		if err != nil {
+			return err
+		}
+
+		*of = append(*of, output)
+	}
+
+	return nil
+}
+
+// Scans projects and writes report.
+func runScan(args []string) int {
+	var (
+		cf           commonFlags
+		baselineFile string
+		outputFile   string
+		outputFormat string
+		outputs      outputsFlag
+		templatePath string
+	)
+
+	fs := newFlagSet("scan", "", "Scans projects for dependencies, detects their licenses and writes report.",
+		"  0 - report was written\n  1 - error appeared\n  2 - invalid parameters\n  3 - license changed comparing to baseline report")
+	cf.registerScan(fs)
+	fs.StringVar(&outputFormat, "outformat", "csv", "Output file format: 'csv', 'html' (self-contained HTML report), 'notice' (third party notices as plain text), 'notice-markdown' (third party notices as Markdown), 'pdf' (printable report), 'template' (user-supplied Go template) or 'xlsx' (Excel workbook).")
+	fs.StringVar(&outputFile, "outfile", "", "File to write licensing information to. Use '-' to write to standard output.")
+	fs.Var(&outputs, "output", "Additional report to write as 'format:path' pair, e.g. 'notice:THIRD_PARTY_NOTICES'. Can be passed several times or contain several comma-separated pairs. Path '-' means standard output.")
+	fs.StringVar(&templatePath, "template", "", "Path to Go template file for 'template' output format. Overrides configuration file value.")
+	fs.StringVar(&baselineFile, "baseline", "", "Previously written CSV report to compare current report with. Changes are printed in Markdown. Optional.")
+
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if outputFile != "" {
+		outputs = append(outputsFlag{{Format: outputFormat, Path: outputFile}}, outputs...)
+	}
+
+	if len(outputs) == 0 {
+		fmt.Fprintln(fs.Output(), "Output file path should be defined with '-outfile' or '-output'.")
+		fs.Usage()
+
+		return exitCodeUsage
+	}
+
+	if ok, code := cf.initializeScan(fs); !ok {
+		return code
+	}
+
+	if templatePath != "" {
+		configuration.Cfg.Outputs.Template.Path = templatePath
+	}
+
+	// Outputs are validated before scanning as scanning might take a
+	// while.
+	stdoutUsed := false
+
+	for _, output := range outputs {
+		if !outputters.IsRegistered(output.Format) {
+			fmt.Fprintln(fs.Output(), "Unknown output format '"+output.Format+"'.")
+			return exitCodeUsage
+		}
+
+		if output.Format == "template" {
+			if err := template.Check(); err != nil {
+				fmt.Fprintln(fs.Output(), "Invalid template: "+err.Error())
+				return exitCodeUsage
+			}
+		}
+
+		if output.Path != outputters.StdoutPath {
+			continue
+		}
+
+		if stdoutUsed {
+			fmt.Fprintln(fs.Output(), "Only one report can be written to standard output.")
+			return exitCodeUsage
+		}
+
+		stdoutUsed = true
+	}
+
+	// Everything else glp prints goes to stderr if report is streamed
+	// to stdout.
+	resultOutput := io.Writer(os.Stdout)
+
+	if stdoutUsed {
+		resultOutput = os.Stderr
+		diagnostics.SetOutput(os.Stderr)
+	}
+
+	deps := projecter.Parse()
+
+	for _, output := range outputs {
+		if err := outputters.Write(output.Format, output.Path, deps); err != nil {
+			logger.Error("Failed to write report:", err.Error())
+			return exitCodeError
+		}
+	}
+
+	diagnostics.Report(deps)
+
+	if baselineFile != "" {
+		return compareWithBaseline(baselineFile, deps, resultOutput)
+	}
+
+	return exitCodeOK
+}
@@ -2,9 +2,10 @@ package configuration
 
 import (
 	// stdlib
-	"flag"
-	"log"
 	"os"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
 )
 
 var (
@@ -15,15 +16,22 @@ var (
 
 // Initialize initializes package.
 func Initialize(cfgpath string) {
-	log.Println("Initializing configuration")
+	logger.Debug("Initializing configuration")
 
 	configurationPath = cfgpath
 
 	Cfg = &config{}
 	err := Cfg.initialize()
 	if err != nil {
-		log.Println("Error appeared when loading configuration:", err.Error())
-		flag.PrintDefaults()
+		logger.Error("Error appeared when loading configuration:", err.Error())
 		os.Exit(1)
 	}
 }
+
+// InitializeDefaults initializes package with empty configuration, so
+// default values are used everywhere. It is used when configuration
+// file is optional.
+func InitializeDefaults() {
+	configurationPath = ""
+	Cfg = &config{}
+}
@@ -2,19 +2,143 @@ package configuration
 
 import (
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"gopkg.in/yaml.v2"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
 )
 
 // This structure holds whole configuration for glp.
 type config struct {
+	// Aggregate is a mode of merging same dependencies from different
+	// projects. Can be "none", "version" or "module".
+	Aggregate string `yaml:"aggregate"`
+
+	Cache struct {
+		// Disabled disables cache usage.
+		Disabled bool `yaml:"disabled"`
+		// Path is a path to cache directory. Default is "glp"
+		// directory in user's cache directory.
+		Path string `yaml:"path"`
+		// TTL is a cached data lifetime, e.g. "24h". Default is 7
+		// days.
+		TTL string `yaml:"ttl"`
+	} `yaml:"cache"`
+	Copyrights struct {
+		// ScanSources enables copyrights extraction from source files
+		// headers. License, NOTICE, AUTHORS and COPYING files are
+		// always scanned.
+		ScanSources bool `yaml:"scan_sources"`
+	} `yaml:"copyrights"`
+	Golang struct {
+		// LinkedOnly restricts report to modules providing packages
+		// compiled into binaries built from Mains for every
+		// platform from Platforms with Tags. Test-only and tools
+		// dependencies are excluded. Setting any of Mains,
+		// Platforms or Tags also enables it.
+		LinkedOnly bool `yaml:"linked_only"`
+		// Mains is a list of main packages patterns relative to
+		// project's directory, e.g. "./cmd/app". Default is
+		// "./...".
+		Mains []string `yaml:"mains"`
+		// Platforms is a list of "GOOS/GOARCH" pairs, e.g.
+		// "linux/amd64". Default is current platform.
+		Platforms []string `yaml:"platforms"`
+		// Reverify disables caching of modules content hashes, so
+		// every module is hashed on every run. By default hash of
+		// module which content matched checksum is cached until any
+		// module's file is changed.
+		Reverify bool `yaml:"reverify"`
+		// Tags is a list of build tags.
+		Tags []string `yaml:"tags"`
+	} `yaml:"golang"`
+	// Jobs is a maximum number of simultaneously executed network
+	// requests and license scans. Zero means number of CPUs.
+	Jobs int `yaml:"jobs"`
+
+	Licenses struct {
+		// MinConfidence is a minimum confidence (from 0 to 1) of
+		// concluded license. Licenses detected with lower confidence
+		// are reported as "NeedsReview". Zero disables check.
+		MinConfidence float64 `yaml:"min_confidence"`
+		// Nested enables detection of licenses in dependencies'
+		// subdirectories (e.g. bundled third-party code). For Go
+		// projects "go list" is used to determine if packages in
+		// such subdirectories are compiled into binaries, see
+		// Golang section.
+		Nested bool `yaml:"nested"`
+		// Prefer is a list of preferred licenses. If dependency is
+		// licensed under choice of licenses (e.g. "MIT OR
+		// Apache-2.0") - first preferred license is concluded,
+		// otherwise the most confident one.
+		Prefer []string `yaml:"prefer"`
+		// Threshold is a minimum confidence (from 0 to 1) of license
+		// matched in separate file for it to be a part of license
+		// expression. Default is 0.8.
+		Threshold float64 `yaml:"threshold"`
+	} `yaml:"licenses"`
+
 	Log struct {
+		// Debug is a deprecated way to enable debug logging. Use
+		// Level instead.
 		Debug bool `yaml:"debug"`
+		// Format is a log lines format. Can be "text" or "json".
+		Format string `yaml:"format"`
+		// Level is a logging level. Can be "error", "warn", "info",
+		// "debug" or "trace".
+		Level string `yaml:"level"`
 	} `yaml:"log"`
+
+	Outputs struct {
+		CSV struct {
+			// Columns is a list of columns to write, in order. Can
+			// contain "module", "version", "license",
+			// "license_expression", "confidence",
+			// "detection_method", "license_file", "repository_url",
+			// "license_url", "project", "copyrights", "notice_file",
+			// "notice" (NOTICE file text), "nested_licenses"
+			// (licenses of subdirectories), "checksum" and
+			// "verification" (checksum verification result: "ok",
+			// "mismatch" or "failed"). By default "module",
+			// "version", "license", "repository_url", "license_url",
+			// "project" and "copyrights" are written.
+			Columns []string `yaml:"columns"`
+			// CopyrightsSeparator is a string copyrights are joined
+			// with in single cell. Default is ",".
+			CopyrightsSeparator string `yaml:"copyrights_separator"`
+			// Delimiter is a fields delimiter. Default is ";". Use
+			// "tab" for tabulation.
+			Delimiter string `yaml:"delimiter"`
+			// Headers overrides header labels. Keys are column names.
+			Headers map[string]string `yaml:"headers"`
+			// Quote is a fields quoting mode: "minimal" (only fields
+			// that require quoting) or "all". Default is "minimal".
+			Quote string `yaml:"quote"`
+		} `yaml:"csv"`
+
+		Template struct {
+			// Engine is a template engine: "text" (text/template) or
+			// "html" (html/template). By default it is determined by
+			// template file extension.
+			Engine string `yaml:"engine"`
+			// Path is a path to template file.
+			Path string `yaml:"path"`
+		} `yaml:"template"`
+	} `yaml:"outputs"`
+
+	Policy struct {
+		// Allowed is a list of allowed licenses. If empty - every
+		// license not in Denied list is allowed.
+		Allowed []string `yaml:"allowed"`
+		// AllowUnknown allows dependencies with unknown licenses.
+		AllowUnknown bool `yaml:"allow_unknown"`
+		// Denied is a list of denied licenses.
+		Denied []string `yaml:"denied"`
+	} `yaml:"policy"`
 }
 
 // Tries to parse configuration.
@@ -43,7 +167,7 @@ func (c *config) initialize() error {
 
 	configurationPath = absPath
 
-	log.Println("Trying to load configuration file data from '" + configurationPath + "'")
+	logger.Debug("Trying to load configuration file data from '" + configurationPath + "'")
 
 	// Read file into memory.
 	fileData, err2 := ioutil.ReadFile(configurationPath)
@@ -57,9 +181,7 @@ func (c *config) initialize() error {
 		return err3
 	}
 
-	if c.Log.Debug {
-		log.Printf("Configuration parsed: %+v\n", c)
-	}
+	logger.Tracef("Configuration parsed: %+v", c)
 
 	return nil
 }
@@ -0,0 +1,32 @@
+package configuration
+
+import (
+	// stdlib
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// InitializeForTest loads configuration from passed YAML data. Previous
+// configuration is restored when test finishes.
+func InitializeForTest(t testing.TB, data string) {
+	t.Helper()
+
+	previousPath, previousCfg := configurationPath, Cfg
+
+	t.Cleanup(func() {
+		configurationPath, Cfg = previousPath, previousCfg
+	})
+
+	path := filepath.Join(t.TempDir(), "glp.yaml")
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configurationPath = path
+
+	Cfg = &config{}
+	if err := Cfg.initialize(); err != nil {
+		t.Fatal("failed to load test configuration: " + err.Error())
+	}
+}
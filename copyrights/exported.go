@@ -0,0 +1,300 @@
+package copyrights
+
+import (
+	// stdlib
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Files bigger than that aren't scanned.
+	maxFileSize = 1024 * 1024
+	// Only that much lines from the beginning of source file are
+	// scanned for header.
+	maxHeaderLines = 50
+	// Maximum number of lines and words holder might be wrapped to.
+	maxWrappedLines = 2
+	maxWrappedWords = 8
+)
+
+var (
+	// Prefixes of files names (in lower case) that are scanned for
+	// copyrights in dependency's root directory.
+	noticeFilesPrefixes = []string{"authors", "contributors", "copying", "copyright", "licence", "license", "notice", "unlicense"}
+
+	// Extensions of source files which headers are scanned.
+	sourceExtensions = map[string]bool{
+		".c": true, ".cc": true, ".cpp": true, ".go": true, ".h": true, ".hpp": true, ".java": true, ".js": true,
+		".proto": true, ".py": true, ".rs": true, ".s": true, ".sh": true, ".ts": true,
+	}
+
+	// Directories that aren't scanned for sources.
+	skippedDirs = map[string]bool{"node_modules": true, "testdata": true, "vendor": true}
+)
+
+// Collector collects copyright statements from several sources and
+// deduplicates them. Statements of same holder are merged with their
+// years combined.
+type Collector struct {
+	holders    []string
+	statements map[string]*statement
+}
+
+// NewCollector creates new collector.
+func NewCollector() *Collector {
+	return &Collector{statements: make(map[string]*statement)}
+}
+
+// Add extracts copyright statements from passed text.
+func (c *Collector) Add(text string) {
+	lines := strings.Split(strings.Replace(text, "\r\n", "\n", -1), "\n")
+
+	for idx := 0; idx < len(lines); idx++ {
+		s, column, ok := parseStatement(lines[idx])
+		if !ok {
+			continue
+		}
+
+		// Long holder might be wrapped to following lines that aren't
+		// aligned with it, e.g. "Portions Copyright (c) 2009 The Go"
+		// followed by "Authors. All rights reserved.".
+		last := s
+		for last.nested != nil {
+			last = last.nested
+		}
+
+		if last.holder != "" && !last.terminated {
+			if continuation, count := wrappedHolder(lines[idx+1:], column); count > 0 {
+				last.holder = normalizeHolder(last.holder + " " + continuation)
+				last.terminated = true
+				idx += count
+			}
+		}
+
+		for nested := s; nested != nil; nested = nested.nested {
+			if nested.holder != "" {
+				c.add(nested)
+			}
+		}
+
+		// Holders might be listed on following lines, either when
+		// statement contains no holder ("Copyright:" followed by list)
+		// or when they are aligned with holder in statement line.
+		for idx+1 < len(lines) {
+			next := lines[idx+1]
+			indent := len(next) - len(strings.TrimLeft(next, " \t"))
+
+			if strings.TrimSpace(next) == "" || (s.holder != "" && indent < column) || (s.holder == "" && indent == 0) {
+				break
+			}
+
+			if _, _, isStatement := parseStatement(next); isStatement {
+				break
+			}
+
+			holder := parseHolder(next)
+			if len(holder.years) == 0 {
+				holder.merge(s)
+			}
+
+			if holder.holder != "" {
+				c.add(holder)
+			}
+
+			idx++
+		}
+	}
+}
+
+// Returns holder's continuation from lines following statement which
+// holder has no terminator and number of lines it takes. Continuation
+// lines aren't aligned with holder and should end holder with
+// terminator within few lines and words, otherwise they are considered
+// to be unrelated text (e.g. license text right after statement).
+func wrappedHolder(lines []string, column int) (string, int) {
+	parts := make([]string, 0, maxWrappedLines)
+	terminated := false
+
+	for idx := 0; idx < len(lines) && idx < maxWrappedLines && !terminated; idx++ {
+		line := lines[idx]
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		text := strings.TrimSpace(line[len(commentMarkers.FindString(line)):])
+
+		if text == "" || indent >= column {
+			return "", 0
+		}
+
+		if _, _, isStatement := parseStatement(line); isStatement {
+			return "", 0
+		}
+
+		if end := terminatorIndex(text); end >= 0 {
+			text = text[:end]
+			terminated = true
+		}
+
+		parts = append(parts, text)
+	}
+
+	continuation := strings.Join(parts, " ")
+	if !terminated || continuation == "" || len(strings.Fields(continuation)) > maxWrappedWords {
+		return "", 0
+	}
+
+	return continuation, len(parts)
+}
+
+// AddFile extracts copyright statements from file. If headerOnly is
+// true - only leading comments block is scanned.
+func (c *Collector) AddFile(path string, headerOnly bool) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if fi.Size() > maxFileSize {
+		return nil
+	}
+
+	if !headerOnly {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		c.Add(string(data))
+
+		return nil
+	}
+
+	f, err1 := os.Open(path)
+	if err1 != nil {
+		return err1
+	}
+	defer f.Close()
+
+	var header strings.Builder
+
+	scanner := bufio.NewScanner(f)
+
+	for lineIdx := 0; lineIdx < maxHeaderLines && scanner.Scan(); lineIdx++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		// Header ends on first code line.
+		if trimmed != "" && commentMarkers.FindString(line) == "" && !strings.HasPrefix(trimmed, "#!") {
+			break
+		}
+
+		header.WriteString(line + "\n")
+	}
+
+	c.Add(header.String())
+
+	return scanner.Err()
+}
+
+// AddDirectory extracts copyright statements from license, NOTICE,
+// AUTHORS, COPYING and similar files in directory root. Passed license
+// file (relative to directory) is scanned first. If scanSources is
+// true - headers of source files in whole directory tree are scanned
+// too.
+func (c *Collector) AddDirectory(dir string, licenseFile string, scanSources bool) error {
+	if licenseFile != "" {
+		if err := c.AddFile(filepath.Join(dir, licenseFile), false); err != nil {
+			return err
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || file.Name() == licenseFile || !isNoticeFile(file.Name()) {
+			continue
+		}
+
+		if err := c.AddFile(filepath.Join(dir, file.Name()), false); err != nil {
+			return err
+		}
+	}
+
+	if !scanSources {
+		return nil
+	}
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && (skippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !sourceExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+
+		return c.AddFile(path, true)
+	})
+}
+
+// Copyrights returns normalized and deduplicated copyright statements in
+// order they were found.
+func (c *Collector) Copyrights() []string {
+	result := make([]string, 0, len(c.holders))
+
+	for _, holder := range c.holders {
+		result = append(result, c.statements[holder].String())
+	}
+
+	return result
+}
+
+// Adds statement, merging it with already known statement of same
+// holder.
+func (c *Collector) add(s *statement) {
+	key := strings.ToLower(s.holder)
+
+	existing, found := c.statements[key]
+	if found {
+		existing.merge(s)
+		return
+	}
+
+	c.statements[key] = s
+	c.holders = append(c.holders, key)
+}
+
+// Extract returns normalized and deduplicated copyright statements found
+// in passed text.
+func Extract(text string) []string {
+	c := NewCollector()
+	c.Add(text)
+
+	return c.Copyrights()
+}
+
+// Returns true if file name looks like license, NOTICE, AUTHORS or
+// similar file.
+func isNoticeFile(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, prefix := range noticeFilesPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
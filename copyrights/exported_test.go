@@ -0,0 +1,100 @@
+package copyrights
+
+import (
+	// stdlib
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		copyrights []string
+	}{
+		{
+			name:       "simple statement",
+			text:       "MIT License\n\nCopyright (c) 2018 John Doe\n\nPermission is hereby granted",
+			copyrights: []string{"Copyright (c) 2018 John Doe"},
+		},
+		{
+			name:       "rights reserved",
+			text:       "Copyright 2015 Google Inc. All rights reserved.",
+			copyrights: []string{"Copyright (c) 2015 Google Inc"},
+		},
+		{
+			name:       "years ranges",
+			text:       "Copyright © 2010, 2012-2014, 2015 John Doe",
+			copyrights: []string{"Copyright (c) 2010, 2012-2015 John Doe"},
+		},
+		{
+			name:       "present year",
+			text:       "Copyright (C) 2016-present Jane Doe",
+			copyrights: []string{"Copyright (c) 2016-present Jane Doe"},
+		},
+		{
+			name:       "merged holders",
+			text:       "Copyright 2010 John Doe\nCopyright 2012 john doe",
+			copyrights: []string{"Copyright (c) 2010, 2012 John Doe"},
+		},
+		{
+			name:       "nested statement",
+			text:       "Copyright 2012 John Doe. Portions Copyright 2009 The Go Authors.",
+			copyrights: []string{"Copyright (c) 2012 John Doe", "Copyright (c) 2009 The Go Authors"},
+		},
+		{
+			name: "holder wrapped to unindented line",
+			text: "Copyright (c) 2012 Péter Surányi. Portions Copyright (c) 2009 The Go\nAuthors. All rights reserved.\n\n" +
+				"Redistribution and use in source and binary forms, with or without\nmodification, are permitted",
+			copyrights: []string{"Copyright (c) 2012 Péter Surányi", "Copyright (c) 2009 The Go Authors"},
+		},
+		{
+			name:       "holder wrapped in comment",
+			text:       "// Copyright 2009 The Go\n// Authors. All rights reserved.\n\npackage foo",
+			copyrights: []string{"Copyright (c) 2009 The Go Authors"},
+		},
+		{
+			name:       "license text after statement",
+			text:       "Copyright (c) 2014 John Doe\nPermission is hereby granted, free of charge, to any person obtaining a copy\nof this software and associated documentation files (the \"Software\"), to deal.",
+			copyrights: []string{"Copyright (c) 2014 John Doe"},
+		},
+		{
+			name:       "license header after statement",
+			text:       "// Copyright 2019 Google LLC\n//\n// Licensed under the Apache License, Version 2.0 (the \"License\");",
+			copyrights: []string{"Copyright (c) 2019 Google LLC"},
+		},
+		{
+			name:       "aligned holders",
+			text:       "Copyright (c) 2015 John Doe\n                   Jane Doe",
+			copyrights: []string{"Copyright (c) 2015 John Doe", "Copyright (c) 2015 Jane Doe"},
+		},
+		{
+			name:       "holders list",
+			text:       "Copyright:\n  2014 John Doe\n  2015 Jane Doe\n",
+			copyrights: []string{"Copyright (c) 2014 John Doe", "Copyright (c) 2015 Jane Doe"},
+		},
+		{
+			name:       "license prose",
+			text:       "The above copyright notice and this permission notice shall be included.\nCopyright holders are not liable.",
+			copyrights: []string{},
+		},
+		{
+			name:       "template placeholder",
+			text:       "Copyright [yyyy] [name of copyright owner]",
+			copyrights: []string{},
+		},
+		{
+			name:       "sign without year",
+			text:       "(c) the Software",
+			copyrights: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if copyrights := Extract(test.text); !reflect.DeepEqual(copyrights, test.copyrights) {
+				t.Errorf("Extract() = %q, want %q", copyrights, test.copyrights)
+			}
+		})
+	}
+}
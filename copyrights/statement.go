@@ -0,0 +1,246 @@
+package copyrights
+
+import (
+	// stdlib
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// Matches copyright statement start: "Copyright", "(c)" or "©" sign
+	// possibly combined, e.g. "Copyright (C)" or "Copyright ©".
+	statementStart = regexp.MustCompile(`(?i)^(copyright\s*(\(c\)|©)?|\(c\)|©)(\s+|:|$)`)
+	// Matches years list in the beginning of statement, e.g.
+	// "2010, 2012-2015, ".
+	yearsList = regexp.MustCompile(`(?i)^((19|20)\d\d(\s*[-–]\s*((19|20)\d\d|present))?\s*,?\s*)+`)
+	// Matches single years range or year.
+	yearsRange = regexp.MustCompile(`(?i)((19|20)\d\d)(\s*[-–]\s*((19|20)\d\d|present))?`)
+	// Matches "All rights reserved" and everything after it.
+	rightsReserved = regexp.MustCompile(`(?i)[.,;]?\s*all\s+rights?\s+reserved.*$`)
+	// Comment markers that might precede statement in source files or
+	// text files.
+	commentMarkers = regexp.MustCompile(`^\s*(//+|/\*+|\*+/?|#+|;+|--+|<!--|%+|\{-|"""|''')?\s*`)
+	// Matches another statement within same line, e.g.
+	// "Copyright 2012 John Doe. Portions Copyright 2009 The Go Authors".
+	nestedStatement = regexp.MustCompile(`(?i)[.;,]\s+(portions\s+)?(copyright|\(c\)|©)`)
+	// Placeholders from license templates (e.g. Apache-2.0 appendix).
+	placeholder = regexp.MustCompile(`(?i)\[yyyy\]|\{yyyy\}|<year>|\[year\]|\{year\}|name of (copyright )?(owner|author)`)
+)
+
+// Abbreviations that end with dot which shouldn't be removed from
+// holder name.
+var abbreviations = map[string]bool{
+	"b.v": true, "co": true, "corp": true, "inc": true, "jr": true, "l.l.c": true, "ltd": true, "n.v": true,
+	"s.a": true, "s.l": true, "sr": true,
+}
+
+// Words that follow "copyright" word in license prose, not in copyright
+// statements ("copyright notice", "copyright holders" and so on).
+var proseWords = map[string]bool{
+	"and": true, "holder": true, "holders": true, "in": true, "interest": true, "is": true, "law": true,
+	"laws": true, "license": true, "licence": true, "notice": true, "notices": true, "of": true, "or": true,
+	"owner": true, "owners": true, "protection": true, "statement": true, "statements": true, "to": true,
+}
+
+// This structure represents copyright statement: holder and years.
+type statement struct {
+	holder  string
+	years   map[int]bool
+	present bool
+	// Another statement that was written in same line.
+	nested *statement
+	// True if statement line ends holder with dot or "All rights
+	// reserved" phrase, so holder isn't wrapped to following lines.
+	terminated bool
+}
+
+// Parses line as copyright statement. Returns statement (with possibly
+// empty holder if it is placed on following lines), column where
+// holder starts in line and true if line is a copyright statement.
+func parseStatement(line string) (*statement, int, bool) {
+	prefix := commentMarkers.FindString(line)
+	text := strings.TrimRight(line[len(prefix):], " \t*/")
+
+	match := statementStart.FindStringSubmatch(text)
+	if match == nil || placeholder.MatchString(text) {
+		return nil, 0, false
+	}
+
+	keywordOnly := !strings.HasPrefix(strings.ToLower(match[1]), "copyright")
+	rest := strings.TrimSpace(text[len(match[0]):])
+
+	// Repeated signs, e.g. "Copyright (c) (c) 2015".
+	for strings.HasPrefix(strings.ToLower(rest), "(c)") || strings.HasPrefix(rest, "©") {
+		rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(rest, "(c)"), "(C)"), "©"))
+	}
+
+	firstWord := strings.ToLower(strings.Trim(strings.SplitN(rest+" ", " ", 2)[0], ".,;:"))
+	if match[2] == "" && proseWords[firstWord] {
+		return nil, 0, false
+	}
+
+	s := &statement{years: make(map[int]bool)}
+
+	years := yearsList.FindString(rest)
+	if years != "" {
+		s.addYears(years)
+		rest = rest[len(years):]
+	} else if keywordOnly {
+		// "(c)" alone is used in many other contexts, so year is
+		// required for it.
+		return nil, 0, false
+	}
+
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(strings.ToLower(rest), "by ") {
+		rest = rest[3:]
+	}
+
+	s.terminated = terminatorIndex(rest) >= 0
+
+	if loc := nestedStatement.FindStringSubmatchIndex(rest); loc != nil {
+		nested, _, ok := parseStatement(rest[loc[4]:])
+		if ok {
+			s.nested = nested
+			s.terminated = true
+			rest = rest[:loc[0]]
+		}
+	}
+
+	s.holder = normalizeHolder(rest)
+
+	column := len(line)
+	if rest != "" {
+		column = strings.Index(line, rest)
+	}
+
+	return s, column, true
+}
+
+// Parses holder line that follows copyright statement. It might contain
+// years too.
+func parseHolder(line string) *statement {
+	prefix := commentMarkers.FindString(line)
+	text := strings.TrimSpace(line[len(prefix):])
+
+	s := &statement{years: make(map[int]bool)}
+
+	years := yearsList.FindString(text)
+	if years != "" {
+		s.addYears(years)
+		text = text[len(years):]
+	}
+
+	s.holder = normalizeHolder(text)
+
+	return s
+}
+
+// Returns index where holder ends in passed text: after dot ending a
+// word (e.g. "Authors." or "Inc.") or before "All rights reserved"
+// phrase. Returns -1 if there is no such terminator.
+func terminatorIndex(text string) int {
+	end := -1
+	if loc := rightsReserved.FindStringIndex(text); loc != nil {
+		end = loc[0]
+	}
+
+	if dot := strings.Index(text+" ", ". "); dot >= 0 && (end < 0 || dot+1 < end) {
+		end = dot + 1
+	}
+
+	return end
+}
+
+// Adds years from passed list, e.g. "2010, 2012-2015".
+func (s *statement) addYears(list string) {
+	for _, match := range yearsRange.FindAllStringSubmatch(list, -1) {
+		start, _ := strconv.Atoi(match[1])
+		end := start
+
+		switch {
+		case strings.EqualFold(match[4], "present"):
+			s.present = true
+		case match[4] != "":
+			end, _ = strconv.Atoi(match[4])
+		}
+
+		// Protection against garbage like "2015-1990".
+		if end < start || end-start > 100 {
+			end = start
+		}
+
+		for year := start; year <= end; year++ {
+			s.years[year] = true
+		}
+	}
+}
+
+// Merges other statement's years into this one.
+func (s *statement) merge(other *statement) {
+	for year := range other.years {
+		s.years[year] = true
+	}
+
+	s.present = s.present || other.present
+}
+
+// Returns normalized statement text, e.g.
+// "Copyright (c) 2010, 2012-2015 John Doe".
+func (s *statement) String() string {
+	years := make([]int, 0, len(s.years))
+	for year := range s.years {
+		years = append(years, year)
+	}
+
+	sort.Ints(years)
+
+	ranges := make([]string, 0)
+
+	for idx := 0; idx < len(years); {
+		end := idx
+		for end+1 < len(years) && years[end+1] == years[end]+1 {
+			end++
+		}
+
+		r := strconv.Itoa(years[idx])
+		if end > idx {
+			r += "-" + strconv.Itoa(years[end])
+		}
+
+		ranges = append(ranges, r)
+		idx = end + 1
+	}
+
+	if s.present && len(ranges) > 0 {
+		last := ranges[len(ranges)-1]
+		ranges[len(ranges)-1] = strings.SplitN(last, "-", 2)[0] + "-present"
+	}
+
+	result := "Copyright (c)"
+	if len(ranges) > 0 {
+		result += " " + strings.Join(ranges, ", ")
+	}
+
+	return result + " " + s.holder
+}
+
+// Normalizes copyright holder: removes "All rights reserved" phrase,
+// trailing punctuation and extra whitespaces.
+func normalizeHolder(holder string) string {
+	holder = rightsReserved.ReplaceAllString(holder, "")
+	holder = strings.Join(strings.Fields(holder), " ")
+	holder = strings.TrimLeft(holder, ",;: ")
+	holder = strings.TrimRight(holder, ",;: ")
+
+	if strings.HasSuffix(holder, ".") {
+		words := strings.Fields(holder)
+		if !abbreviations[strings.ToLower(strings.TrimSuffix(words[len(words)-1], "."))] {
+			holder = strings.TrimRight(holder, ".,;: ")
+		}
+	}
+
+	return holder
+}
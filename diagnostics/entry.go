@@ -0,0 +1,94 @@
+package diagnostics
+
+// Severity is a diagnostic entry severity.
+type Severity string
+
+const (
+	// SeverityWarning marks problems that makes report less complete
+	// but doesn't make it wrong.
+	SeverityWarning Severity = "warning"
+	// SeverityError marks problems that makes report incorrect or
+	// incomplete for some dependency.
+	SeverityError Severity = "error"
+)
+
+// Code is a machine-readable diagnostic code.
+type Code string
+
+const (
+	// CodeHTTPRequestFailed is used when HTTP request failed even
+	// after retries.
+	CodeHTTPRequestFailed Code = "http-request-failed"
+	// CodeHTTPBodyReadFailed is used when HTTP response body cannot
+	// be read.
+	CodeHTTPBodyReadFailed Code = "http-body-read-failed"
+	// CodeVCSDataFetchFailed is used when go-import and go-source data
+	// cannot be obtained for dependency.
+	CodeVCSDataFetchFailed Code = "vcs-data-fetch-failed"
+	// CodeVCSDataParseFailed is used when go-import and go-source data
+	// cannot be parsed.
+	CodeVCSDataParseFailed Code = "vcs-data-parse-failed"
+	// CodeDependenciesReadFailed is used when parser failed to read
+	// dependencies list (e.g. go.sum or Gopkg.lock).
+	CodeDependenciesReadFailed Code = "dependencies-read-failed"
+	// CodeModuleDownloadFailed is used when module compiled into
+	// executable isn't in modules cache and cannot be downloaded.
+	CodeModuleDownloadFailed Code = "module-download-failed"
+	// CodeModuleChecksumMismatch is used when module content doesn't
+	// match checksum from go.sum or executable's build information.
+	CodeModuleChecksumMismatch Code = "module-checksum-mismatch"
+	// CodeModuleVerificationFailed is used when module content cannot
+	// be read to compute checksum.
+	CodeModuleVerificationFailed Code = "module-verification-failed"
+	// CodeModuleNotFound is used when module from go.sum isn't in
+	// modules cache.
+	CodeModuleNotFound Code = "module-not-found"
+	// CodeProjectNotSupported is used when no parser can handle project.
+	CodeProjectNotSupported Code = "project-not-supported"
+	// CodeLicenseScanFailed is used when dependency's directory cannot
+	// be scanned for licenses.
+	CodeLicenseScanFailed Code = "license-scan-failed"
+	// CodeLicenseNotFound is used when no license was detected for
+	// dependency.
+	CodeLicenseNotFound Code = "license-not-found"
+	// CodeLicenseFileReadFailed is used when license file was detected
+	// but cannot be read.
+	CodeLicenseFileReadFailed Code = "license-file-read-failed"
+	// CodeLicenseNeedsReview is used when license was detected with
+	// confidence lower than configured minimum.
+	CodeLicenseNeedsReview Code = "license-needs-review"
+	// CodeLicenseURLMissing is used when license URL cannot be composed.
+	CodeLicenseURLMissing Code = "license-url-missing"
+	// CodeRepositoryURLMissing is used when repository URL is unknown.
+	CodeRepositoryURLMissing Code = "repository-url-missing"
+	// CodeCopyrightsMissing is used when no copyrights was found.
+	CodeCopyrightsMissing Code = "copyrights-missing"
+	// CodeNoticeFileReadFailed is used when NOTICE file was found but
+	// cannot be read.
+	CodeNoticeFileReadFailed Code = "notice-file-read-failed"
+	// CodeNestedLicenseFound is used when dependency's subdirectory is
+	// licensed differently than dependency itself.
+	CodeNestedLicenseFound Code = "nested-license-found"
+	// CodePackagesListFailed is used when packages compiled into
+	// project's binaries cannot be listed.
+	CodePackagesListFailed Code = "packages-list-failed"
+	// CodeLicenseDiffersBetweenVersions is used when different versions
+	// of same dependency are licensed differently.
+	CodeLicenseDiffersBetweenVersions Code = "license-differs-between-versions"
+)
+
+// Entry is a single diagnostic record.
+type Entry struct {
+	// Code is a machine-readable problem code.
+	Code Code `json:"code"`
+	// Severity is a problem severity.
+	Severity Severity `json:"severity"`
+	// Subject is a thing problem relates to. Usually it is a
+	// dependency in "name@version" form, but might also be an URL
+	// or project path.
+	Subject string `json:"subject"`
+	// Project is a parent project path, if known.
+	Project string `json:"project,omitempty"`
+	// Message is a human-readable problem description.
+	Message string `json:"message"`
+}
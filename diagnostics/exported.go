@@ -0,0 +1,90 @@
+package diagnostics
+
+import (
+	// stdlib
+	"io"
+	"os"
+	"sync"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+var (
+	diagnosticsFile string
+	summaryOutput   io.Writer = os.Stdout
+
+	entries      []*Entry
+	entriesMutex sync.Mutex
+)
+
+// Initialize initializes package.
+func Initialize(diagFile string) {
+	logger.Debug("Initializing diagnostics collector...")
+
+	diagnosticsFile = diagFile
+	entries = make([]*Entry, 0)
+}
+
+// SetOutput sets writer run summary is printed to. Default is standard
+// output.
+func SetOutput(w io.Writer) {
+	summaryOutput = w
+}
+
+// Add records passed diagnostic entry.
+func Add(entry *Entry) {
+	entriesMutex.Lock()
+	entries = append(entries, entry)
+	entriesMutex.Unlock()
+}
+
+// Entries returns copy of all recorded diagnostic entries.
+func Entries() []*Entry {
+	entriesMutex.Lock()
+	defer entriesMutex.Unlock()
+
+	result := make([]*Entry, len(entries))
+	copy(result, entries)
+
+	return result
+}
+
+// Error records error for passed subject.
+func Error(code Code, subject string, project string, message string) {
+	Add(&Entry{Code: code, Severity: SeverityError, Subject: subject, Project: project, Message: message})
+}
+
+// Warning records warning for passed subject.
+func Warning(code Code, subject string, project string, message string) {
+	Add(&Entry{Code: code, Severity: SeverityWarning, Subject: subject, Project: project, Message: message})
+}
+
+// DependencyError records error for passed dependency.
+func DependencyError(dep *structs.Dependency, code Code, message string) {
+	Error(code, dep.Name+"@"+dep.Version, dep.Parent, message)
+}
+
+// DependencyWarning records warning for passed dependency.
+func DependencyWarning(dep *structs.Dependency, code Code, message string) {
+	Warning(code, dep.Name+"@"+dep.Version, dep.Parent, message)
+}
+
+// Report checks passed dependencies for missing data, prints summary
+// table and writes diagnostics file if it was requested.
+func Report(deps []*structs.Dependency) {
+	checkDependencies(deps)
+
+	summary := newSummary(deps, Entries())
+	summary.print(summaryOutput)
+
+	if diagnosticsFile == "" {
+		return
+	}
+
+	err := writeFile(diagnosticsFile, summary, Entries())
+	if err != nil {
+		logger.Error("Failed to write diagnostics file:", err.Error())
+	}
+}
@@ -0,0 +1,169 @@
+package diagnostics
+
+import (
+	// stdlib
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	// local
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Summary is a run summary used for one-glance health check.
+type Summary struct {
+	// Dependencies is a total number of dependencies in report.
+	Dependencies int `json:"dependencies"`
+	// Licenses is a dependencies count by license name.
+	Licenses map[string]int `json:"licenses"`
+	// UnknownLicenses is a number of dependencies without detected
+	// license.
+	UnknownLicenses int `json:"unknown_licenses"`
+	// NeedsReview is a number of dependencies which license was
+	// detected with confidence lower than configured minimum.
+	NeedsReview int `json:"needs_review"`
+	// MissingLicenseURLs is a number of dependencies without license
+	// URL.
+	MissingLicenseURLs int `json:"missing_license_urls"`
+	// MissingRepositoryURLs is a number of dependencies without
+	// repository URL.
+	MissingRepositoryURLs int `json:"missing_repository_urls"`
+	// MissingCopyrights is a number of dependencies without copyrights.
+	MissingCopyrights int `json:"missing_copyrights"`
+	// Errors is a number of recorded errors.
+	Errors int `json:"errors"`
+	// Warnings is a number of recorded warnings.
+	Warnings int `json:"warnings"`
+	// Codes is a number of recorded diagnostics by code.
+	Codes map[Code]int `json:"codes"`
+}
+
+// This structure is a diagnostics file layout.
+type diagnosticsFileData struct {
+	Summary     *Summary `json:"summary"`
+	Diagnostics []*Entry `json:"diagnostics"`
+}
+
+// Checks dependencies for missing data and records warnings about it.
+func checkDependencies(deps []*structs.Dependency) {
+	for _, dep := range deps {
+		if dep.VCS.VCSPath == "" {
+			DependencyWarning(dep, CodeRepositoryURLMissing, "repository URL is unknown")
+		}
+
+		// Other things are meaningless without license.
+		if dep.License.Name == "" || dep.License.Name == "Unknown" {
+			continue
+		}
+
+		if dep.License.URL == "" {
+			DependencyWarning(dep, CodeLicenseURLMissing, "license URL cannot be composed")
+		}
+
+		if len(dep.License.Copyrights) == 0 {
+			DependencyWarning(dep, CodeCopyrightsMissing, "no copyrights found")
+		}
+	}
+}
+
+// Creates summary for passed dependencies and diagnostic entries.
+func newSummary(deps []*structs.Dependency, diags []*Entry) *Summary {
+	s := &Summary{
+		Dependencies: len(deps),
+		Licenses:     make(map[string]int),
+		Codes:        make(map[Code]int),
+	}
+
+	for _, dep := range deps {
+		licenseName := dep.License.Name
+		if licenseName == "" {
+			licenseName = "Unknown"
+		}
+
+		s.Licenses[licenseName]++
+
+		if licenseName == "Unknown" {
+			s.UnknownLicenses++
+		}
+
+		if licenseName == structs.LicenseNeedsReview {
+			s.NeedsReview++
+		}
+
+		if dep.License.URL == "" {
+			s.MissingLicenseURLs++
+		}
+
+		if dep.VCS.VCSPath == "" {
+			s.MissingRepositoryURLs++
+		}
+
+		if len(dep.License.Copyrights) == 0 {
+			s.MissingCopyrights++
+		}
+	}
+
+	for _, entry := range diags {
+		s.Codes[entry.Code]++
+
+		switch entry.Severity {
+		case SeverityError:
+			s.Errors++
+		case SeverityWarning:
+			s.Warnings++
+		}
+	}
+
+	return s
+}
+
+// Prints summary as table into passed writer.
+func (s *Summary) print(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "LICENSE\tDEPENDENCIES")
+
+	// Sort licenses by usage count, most used first.
+	licenses := make([]string, 0, len(s.Licenses))
+	for name := range s.Licenses {
+		licenses = append(licenses, name)
+	}
+
+	sort.Slice(licenses, func(i, j int) bool {
+		if s.Licenses[licenses[i]] == s.Licenses[licenses[j]] {
+			return licenses[i] < licenses[j]
+		}
+
+		return s.Licenses[licenses[i]] > s.Licenses[licenses[j]]
+	})
+
+	for _, name := range licenses {
+		fmt.Fprintln(tw, name+"\t"+strconv.Itoa(s.Licenses[name]))
+	}
+
+	fmt.Fprintln(tw, "\t")
+	fmt.Fprintln(tw, "Total dependencies\t"+strconv.Itoa(s.Dependencies))
+	fmt.Fprintln(tw, "Unknown licenses\t"+strconv.Itoa(s.UnknownLicenses))
+	fmt.Fprintln(tw, "Licenses to review\t"+strconv.Itoa(s.NeedsReview))
+	fmt.Fprintln(tw, "Missing license URLs\t"+strconv.Itoa(s.MissingLicenseURLs))
+	fmt.Fprintln(tw, "Missing repository URLs\t"+strconv.Itoa(s.MissingRepositoryURLs))
+	fmt.Fprintln(tw, "Missing copyrights\t"+strconv.Itoa(s.MissingCopyrights))
+	fmt.Fprintln(tw, "Errors\t"+strconv.Itoa(s.Errors))
+	fmt.Fprintln(tw, "Warnings\t"+strconv.Itoa(s.Warnings))
+
+	_ = tw.Flush()
+}
+
+// Writes machine-readable diagnostics file.
+func writeFile(filePath string, summary *Summary, diags []*Entry) error {
+	data, err := json.MarshalIndent(&diagnosticsFileData{Summary: summary, Diagnostics: diags}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filePath, data, 0644)
+}
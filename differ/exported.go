@@ -0,0 +1,191 @@
+package differ
+
+import (
+	// stdlib
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/semver"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// This structure holds everything known about module in one report.
+type moduleState struct {
+	licenses map[string]bool
+	versions []string
+}
+
+// Compare reads baseline report from passed path and compares it with
+// passed dependencies.
+func Compare(baselinePath string, deps []*structs.Dependency) (*Result, error) {
+	logger.Info("Comparing dependencies with baseline report '" + baselinePath + "'...")
+
+	baseline, err := readReport(baselinePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareDependencies(baseline.deps, deps, baseline.hasLicenses), nil
+}
+
+// CompareReports reads both baseline and current reports from passed
+// paths and compares them.
+func CompareReports(baselinePath string, currentPath string) (*Result, error) {
+	logger.Info("Comparing report '" + currentPath + "' with baseline report '" + baselinePath + "'...")
+
+	baseline, err := readReport(baselinePath)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err1 := readReport(currentPath)
+	if err1 != nil {
+		return nil, err1
+	}
+
+	return compareDependencies(baseline.deps, current.deps, baseline.hasLicenses && current.hasLicenses), nil
+}
+
+// Compares two lists of dependencies. Dependencies are compared by
+// name, so same module used in several projects is compared once.
+// Licenses aren't compared if they're unknown for one of lists.
+func compareDependencies(baselineDeps []*structs.Dependency, currentDeps []*structs.Dependency, compareLicenses bool) *Result {
+	baseline := collectModules(baselineDeps)
+	current := collectModules(currentDeps)
+
+	result := &Result{}
+
+	// Licenses that was present in baseline.
+	baselineLicenses := make(map[string]bool)
+	for _, state := range baseline {
+		for license := range state.licenses {
+			baselineLicenses[license] = true
+		}
+	}
+
+	newLicenses := make(map[string]bool)
+
+	for _, name := range sortedNames(current) {
+		cur := current[name]
+		change := &Change{
+			Name:       name,
+			NewVersion: strings.Join(cur.versions, ","),
+			NewLicense: joinLicenses(cur.licenses),
+		}
+
+		for license := range cur.licenses {
+			if compareLicenses && !baselineLicenses[license] {
+				newLicenses[license] = true
+			}
+		}
+
+		base, found := baseline[name]
+		if !found {
+			result.Added = append(result.Added, change)
+			continue
+		}
+
+		change.OldVersion = strings.Join(base.versions, ",")
+		change.OldLicense = joinLicenses(base.licenses)
+
+		// Highest versions are compared as they're most significant.
+		switch semver.Compare(cur.versions[len(cur.versions)-1], base.versions[len(base.versions)-1]) {
+		case 1:
+			result.Upgraded = append(result.Upgraded, change)
+		case -1:
+			result.Downgraded = append(result.Downgraded, change)
+		}
+
+		if compareLicenses && change.OldLicense != change.NewLicense {
+			result.LicenseChanged = append(result.LicenseChanged, change)
+		}
+	}
+
+	for _, name := range sortedNames(baseline) {
+		if _, found := current[name]; found {
+			continue
+		}
+
+		base := baseline[name]
+		result.Removed = append(result.Removed, &Change{
+			Name:       name,
+			OldVersion: strings.Join(base.versions, ","),
+			OldLicense: joinLicenses(base.licenses),
+		})
+	}
+
+	for license := range newLicenses {
+		result.NewLicenses = append(result.NewLicenses, license)
+	}
+
+	sort.Strings(result.NewLicenses)
+
+	return result
+}
+
+// Collects modules states from dependencies list.
+func collectModules(deps []*structs.Dependency) map[string]*moduleState {
+	modules := make(map[string]*moduleState)
+
+	for _, dep := range deps {
+		state, found := modules[dep.Name]
+		if !found {
+			state = &moduleState{licenses: make(map[string]bool)}
+			modules[dep.Name] = state
+		}
+
+		state.licenses[dep.License.Name] = true
+
+		// Reports aggregated by module contains all versions in one
+		// field.
+		for _, version := range strings.Split(dep.Version, ",") {
+			version = strings.TrimSpace(version)
+			if !containsString(state.versions, version) {
+				state.versions = append(state.versions, version)
+			}
+		}
+	}
+
+	for _, state := range modules {
+		sort.Slice(state.versions, func(i, j int) bool { return semver.Compare(state.versions[i], state.versions[j]) < 0 })
+	}
+
+	return modules
+}
+
+// Returns true if slice contains passed string.
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns sorted licenses names delimited with comma.
+func joinLicenses(licenses map[string]bool) string {
+	names := make([]string, 0, len(licenses))
+	for name := range licenses {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
+
+// Returns sorted modules names.
+func sortedNames(modules map[string]*moduleState) []string {
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
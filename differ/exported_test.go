@@ -0,0 +1,242 @@
+package differ
+
+import (
+	// stdlib
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	csvoutputter "go.dev.pztrn.name/glp/outputters/csv"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Writes report with passed content into directory and returns it's
+// path.
+func writeReport(t *testing.T, dir string, name string, data string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// Returns names of changed dependencies.
+func changeNames(changes []*Change) []string {
+	names := make([]string, 0, len(changes))
+	for _, change := range changes {
+		names = append(names, change.Name)
+	}
+
+	return names
+}
+
+func TestCompareReports(t *testing.T) {
+	configuration.InitializeForTest(t, "")
+
+	dir := t.TempDir()
+
+	baseline := writeReport(t, dir, "baseline.csv", "Module;Version;License;Project\n"+
+		"example.com/same;v1.0.0;MIT;p1\n"+
+		"example.com/upgraded;v1.0.0;MIT;p1\n"+
+		"example.com/downgraded;v1.10.0;MIT;p1\n"+
+		"example.com/relicensed;v1.0.0;MIT;p1\n"+
+		"example.com/removed;v1.0.0;BSD-3-Clause;p1\n"+
+		"example.com/aggregated;v1.0.0,v1.2.0;MIT;p1,p2\n")
+
+	// Current report uses other delimiter and columns order.
+	current := writeReport(t, dir, "current.csv", "License,Module,Version\n"+
+		"MIT,example.com/same,v1.0.0\n"+
+		"MIT,example.com/upgraded,v1.1.0\n"+
+		"MIT,example.com/downgraded,v1.9.0\n"+
+		"Apache-2.0,example.com/relicensed,v1.0.0\n"+
+		"GPL-3.0,example.com/added,v0.1.0\n"+
+		"MIT,example.com/aggregated,\"v1.2.0,v1.0.0\"\n")
+
+	result, err := CompareReports(baseline, current)
+	if err != nil {
+		t.Fatalf("CompareReports() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		changes []string
+		want    []string
+	}{
+		{"added", changeNames(result.Added), []string{"example.com/added"}},
+		{"removed", changeNames(result.Removed), []string{"example.com/removed"}},
+		{"upgraded", changeNames(result.Upgraded), []string{"example.com/upgraded"}},
+		{"downgraded", changeNames(result.Downgraded), []string{"example.com/downgraded"}},
+		{"license changed", changeNames(result.LicenseChanged), []string{"example.com/relicensed"}},
+		{"new licenses", result.NewLicenses, []string{"Apache-2.0", "GPL-3.0"}},
+	}
+
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.changes, test.want) {
+			t.Errorf("%s = %q, want %q", test.name, test.changes, test.want)
+		}
+	}
+
+	if !result.Failed() || result.IsEmpty() {
+		t.Errorf("Failed(), IsEmpty() = %v, %v, want true, false", result.Failed(), result.IsEmpty())
+	}
+
+	// Report compared with itself has no changes.
+	same, err := CompareReports(baseline, baseline)
+	if err != nil {
+		t.Fatalf("CompareReports() returned error: %v", err)
+	}
+
+	if same.Failed() || !same.IsEmpty() {
+		t.Errorf("Failed(), IsEmpty() = %v, %v for same report, want false, true", same.Failed(), same.IsEmpty())
+	}
+}
+
+func TestReadReportErrors(t *testing.T) {
+	configuration.InitializeForTest(t, "")
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty report", ""},
+		{"no module column", "Name;Version;License\nexample.com/dep;v1.0.0;MIT\n"},
+		{"malformed quotes", "Module;Version;License\n\"example.com/dep;v1.0.0;MIT\n"},
+	}
+
+	for _, test := range tests {
+		if _, err := readReport(writeReport(t, dir, "report.csv", test.data)); err == nil {
+			t.Errorf("readReport() of %s returned no error", test.name)
+		}
+	}
+
+	if _, err := readReport(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Errorf("readReport() of missing file returned no error")
+	}
+}
+
+func TestDetectDelimiter(t *testing.T) {
+	tests := []struct {
+		header     string
+		configured rune
+		delimiter  rune
+	}{
+		{"Module;Version;License", ';', ';'},
+		{"Module,Version,License", ';', ','},
+		{"Module\tVersion\tLicense", ';', '\t'},
+		{"Module;Version;License,Project", ';', ';'},
+		{"Module|Version|License", '|', '|'},
+		{"Module|Version;License", '|', '|'},
+		{"Module;Version;License", '|', ';'},
+		{"Module", ';', ';'},
+		{"Module", '|', '|'},
+	}
+
+	for _, test := range tests {
+		if delimiter := detectDelimiter(test.header, test.configured); delimiter != test.delimiter {
+			t.Errorf("detectDelimiter(%q) = %q, want %q", test.header, delimiter, test.delimiter)
+		}
+	}
+}
+
+func TestReadReportWithCustomSettings(t *testing.T) {
+	deps := []*structs.Dependency{
+		{Name: "example.com/a", Version: "v1.0.0", Parent: "p1", License: structs.License{Name: "MIT"}},
+		{Name: "example.com/b", Version: "v2.1.0", Parent: "p2", License: structs.License{Name: "Apache-2.0"}},
+	}
+
+	tests := []struct {
+		name        string
+		config      string
+		hasLicenses bool
+	}{
+		{
+			name:        "renamed headers and custom delimiter",
+			config:      "    delimiter: \"|\"\n    columns: [license, module, project, version]\n    headers:\n      module: Package\n      license: SPDX\n",
+			hasLicenses: true,
+		},
+		{
+			name:        "quoted fields",
+			config:      "    delimiter: \",\"\n    quote: all\n    headers:\n      version: Release\n",
+			hasLicenses: true,
+		},
+		{
+			name:   "no license column",
+			config: "    delimiter: tab\n    columns: [module, version, project]\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration.InitializeForTest(t, "outputs:\n  csv:\n"+test.config)
+
+			var b bytes.Buffer
+			if err := csvoutputter.Initialize().Write(deps, &b); err != nil {
+				t.Fatalf("Write() returned error: %v", err)
+			}
+
+			r, err := readReport(writeReport(t, t.TempDir(), "report.csv", b.String()))
+			if err != nil {
+				t.Fatalf("readReport() returned error: %v", err)
+			}
+
+			if r.hasLicenses != test.hasLicenses {
+				t.Errorf("hasLicenses = %v, want %v", r.hasLicenses, test.hasLicenses)
+			}
+
+			for idx, dep := range r.deps {
+				want := deps[idx]
+
+				license := want.License.Name
+				if !test.hasLicenses {
+					license = ""
+				}
+
+				if dep.Name != want.Name || dep.Version != want.Version || dep.Parent != want.Parent || dep.License.Name != license {
+					t.Errorf("dependency %d = %s %s %s %s, want %s %s %s %s", idx,
+						dep.Name, dep.Version, dep.Parent, dep.License.Name, want.Name, want.Version, want.Parent, license)
+				}
+			}
+
+			if len(r.deps) != len(deps) {
+				t.Errorf("got %d dependencies, want %d", len(r.deps), len(deps))
+			}
+
+			// Report compared with itself has no changes.
+			result, err := CompareReports(writeReport(t, t.TempDir(), "baseline.csv", b.String()), writeReport(t, t.TempDir(), "current.csv", b.String()))
+			if err != nil || !result.IsEmpty() {
+				t.Errorf("CompareReports() with itself = %+v, %v, want no changes", result, err)
+			}
+		})
+	}
+}
+
+func TestCompareReportsWithoutLicenses(t *testing.T) {
+	configuration.InitializeForTest(t, "")
+
+	dir := t.TempDir()
+
+	baseline := writeReport(t, dir, "baseline.csv", "Module;Version\nexample.com/a;v1.0.0\n")
+	current := writeReport(t, dir, "current.csv", "Module;Version;License\nexample.com/a;v1.1.0;MIT\nexample.com/b;v1.0.0;GPL-3.0\n")
+
+	result, err := CompareReports(baseline, current)
+	if err != nil {
+		t.Fatalf("CompareReports() returned error: %v", err)
+	}
+
+	if len(result.Added) != 1 || len(result.Upgraded) != 1 {
+		t.Errorf("added, upgraded = %d, %d, want 1, 1", len(result.Added), len(result.Upgraded))
+	}
+
+	if result.Failed() {
+		t.Errorf("Failed() = true for baseline without licenses: %q, %q", changeNames(result.LicenseChanged), result.NewLicenses)
+	}
+}
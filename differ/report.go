@@ -0,0 +1,140 @@
+package differ
+
+import (
+	// stdlib
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	csvoutputter "go.dev.pztrn.name/glp/outputters/csv"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Report columns used for comparison, as named in CSV output
+// configuration.
+const (
+	columnModule  = "module"
+	columnVersion = "version"
+	columnLicense = "license"
+	columnProject = "project"
+)
+
+// Delimiters that might be used in CSV reports.
+var possibleDelimiters = []rune{';', ',', '\t'}
+
+// This structure represents CSV report previously written by glp.
+type report struct {
+	deps []*structs.Dependency
+	// Licenses are compared only if both reports contain license
+	// column.
+	hasLicenses bool
+}
+
+// Reads CSV report previously written by glp and returns dependencies
+// from it. Only name, version, license and project are filled. Columns
+// and delimiter are resolved using CSV output configuration, so reports
+// written with custom settings are read too.
+func readReport(filePath string) (*report, error) {
+	delimiter, err0 := csvoutputter.Delimiter()
+	if err0 != nil {
+		return nil, err0
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+
+	// Delimiter is determined using header line.
+	header, err1 := reader.Peek(4096)
+	if err1 != nil && err1 != io.EOF && err1 != bufio.ErrBufferFull {
+		return nil, err1
+	}
+
+	headerLine := strings.SplitN(string(header), "\n", 2)[0]
+
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = detectDelimiter(headerLine, delimiter)
+	csvReader.FieldsPerRecord = -1
+
+	records, err2 := csvReader.ReadAll()
+	if err2 != nil {
+		return nil, err2
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("report is empty")
+	}
+
+	// Find columns positions.
+	columns := make(map[string]int)
+
+	for _, name := range []string{columnModule, columnVersion, columnLicense, columnProject} {
+		if idx := csvoutputter.ColumnIndex(records[0], name); idx != -1 {
+			columns[name] = idx
+		}
+	}
+
+	if _, found := columns[columnModule]; !found {
+		return nil, errors.New("report has no module column, header is '" + strings.Join(records[0], string(csvReader.Comma)) + "'")
+	}
+
+	r := &report{deps: make([]*structs.Dependency, 0, len(records)-1)}
+	_, r.hasLicenses = columns[columnLicense]
+
+	if !r.hasLicenses {
+		logger.Warn("Report '" + filePath + "' has no license column, licenses won't be compared")
+	}
+
+	for _, record := range records[1:] {
+		dep := &structs.Dependency{
+			Name:    field(record, columns, columnModule),
+			Version: field(record, columns, columnVersion),
+			Parent:  field(record, columns, columnProject),
+		}
+		dep.License.Name = field(record, columns, columnLicense)
+
+		if dep.Name == "" {
+			continue
+		}
+
+		r.deps = append(r.deps, dep)
+	}
+
+	return r, nil
+}
+
+// Returns delimiter which produces most fields for passed header line.
+// Configured delimiter is preferred.
+func detectDelimiter(headerLine string, configured rune) rune {
+	delimiter := configured
+	maxCount := 0
+
+	for _, d := range append([]rune{configured}, possibleDelimiters...) {
+		if count := strings.Count(headerLine, string(d)); count > maxCount {
+			delimiter = d
+			maxCount = count
+		}
+	}
+
+	return delimiter
+}
+
+// Returns record's field value for column or empty string if column
+// is absent.
+func field(record []string, columns map[string]int, column string) string {
+	idx, found := columns[column]
+	if !found || idx >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[idx])
+}
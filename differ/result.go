@@ -0,0 +1,153 @@
+package differ
+
+import (
+	// stdlib
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Change describes single dependency change between baseline and
+// current reports.
+type Change struct {
+	// Name is a dependency name.
+	Name string `json:"name"`
+	// OldVersion is a version from baseline report. Empty for added
+	// dependencies.
+	OldVersion string `json:"old_version,omitempty"`
+	// NewVersion is a version from current report. Empty for removed
+	// dependencies.
+	NewVersion string `json:"new_version,omitempty"`
+	// OldLicense is a license from baseline report. Empty for added
+	// dependencies.
+	OldLicense string `json:"old_license,omitempty"`
+	// NewLicense is a license from current report. Empty for removed
+	// dependencies.
+	NewLicense string `json:"new_license,omitempty"`
+}
+
+// Result is a comparison result.
+type Result struct {
+	// Added is a list of dependencies absent in baseline.
+	Added []*Change `json:"added"`
+	// Removed is a list of dependencies absent in current report.
+	Removed []*Change `json:"removed"`
+	// Upgraded is a list of dependencies which version was increased.
+	Upgraded []*Change `json:"upgraded"`
+	// Downgraded is a list of dependencies which version was decreased.
+	Downgraded []*Change `json:"downgraded"`
+	// LicenseChanged is a list of dependencies which license was
+	// changed.
+	LicenseChanged []*Change `json:"license_changed"`
+	// NewLicenses is a list of licenses that wasn't present in
+	// baseline at all.
+	NewLicenses []string `json:"new_licenses"`
+}
+
+// Failed returns true if license was changed for some dependency or
+// license not present in baseline appeared.
+func (r *Result) Failed() bool {
+	return len(r.LicenseChanged) > 0 || len(r.NewLicenses) > 0
+}
+
+// IsEmpty returns true if there are no changes at all.
+func (r *Result) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Upgraded) == 0 && len(r.Downgraded) == 0 && len(r.LicenseChanged) == 0
+}
+
+// Print writes human-readable result in Markdown format, which is
+// suitable for pull request comments.
+func (r *Result) Print(w io.Writer) {
+	fmt.Fprintln(w, "### Dependencies changes")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, r.summary())
+
+	r.printTable(w, "Added", r.Added, []string{"Module", "Version", "License"}, func(c *Change) []string {
+		return []string{c.Name, c.NewVersion, c.NewLicense}
+	})
+	r.printTable(w, "Removed", r.Removed, []string{"Module", "Version", "License"}, func(c *Change) []string {
+		return []string{c.Name, c.OldVersion, c.OldLicense}
+	})
+	r.printTable(w, "Upgraded", r.Upgraded, []string{"Module", "Old version", "New version"}, func(c *Change) []string {
+		return []string{c.Name, c.OldVersion, c.NewVersion}
+	})
+	r.printTable(w, "Downgraded", r.Downgraded, []string{"Module", "Old version", "New version"}, func(c *Change) []string {
+		return []string{c.Name, c.OldVersion, c.NewVersion}
+	})
+	r.printTable(w, "License changed", r.LicenseChanged, []string{"Module", "Old license", "New license"}, func(c *Change) []string {
+		return []string{c.Name, c.OldLicense, c.NewLicense}
+	})
+}
+
+// Returns one-paragraph summary.
+func (r *Result) summary() string {
+	if r.IsEmpty() {
+		return "This change doesn't change dependencies."
+	}
+
+	summary := "This change adds " + plural(len(r.Added), "dependency", "dependencies") +
+		", removes " + strconv.Itoa(len(r.Removed)) +
+		", upgrades " + strconv.Itoa(len(r.Upgraded)) +
+		" and downgrades " + strconv.Itoa(len(r.Downgraded)) + "."
+
+	// Licenses of added dependencies are most interesting thing for
+	// reviewers.
+	addedLicenses := make(map[string]int)
+	for _, c := range r.Added {
+		addedLicenses[c.NewLicense]++
+	}
+
+	if len(addedLicenses) > 0 {
+		names := make([]string, 0, len(addedLicenses))
+		for name := range addedLicenses {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		parts := make([]string, 0, len(names))
+		for _, name := range names {
+			parts = append(parts, strconv.Itoa(addedLicenses[name])+" "+name)
+		}
+
+		summary += " Added dependencies licenses: " + strings.Join(parts, ", ") + "."
+	}
+
+	if len(r.LicenseChanged) > 0 {
+		summary += " " + plural(len(r.LicenseChanged), "dependency", "dependencies") + " changed license."
+	}
+
+	if len(r.NewLicenses) > 0 {
+		summary += " Licenses not present before: " + strings.Join(r.NewLicenses, ", ") + "."
+	}
+
+	return summary
+}
+
+// Writes Markdown table for passed changes, if any.
+func (r *Result) printTable(w io.Writer, title string, changes []*Change, headers []string, row func(c *Change) []string) {
+	if len(changes) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "#### "+title)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| "+strings.Join(headers, " | ")+" |")
+	fmt.Fprintln(w, strings.Repeat("|---", len(headers))+"|")
+
+	for _, c := range changes {
+		fmt.Fprintln(w, "| "+strings.Join(row(c), " | ")+" |")
+	}
+}
+
+// Returns count with properly pluralized noun.
+func plural(count int, singular string, pluralForm string) string {
+	if count == 1 {
+		return "1 " + singular
+	}
+
+	return strconv.Itoa(count) + " " + pluralForm
+}
@@ -0,0 +1,190 @@
+package golist
+
+import (
+	// stdlib
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/logger"
+)
+
+// Package is a package description reported by "go list".
+type Package struct {
+	// Dir is a directory containing package sources.
+	Dir string
+	// ImportPath is a package import path.
+	ImportPath string
+	// Module is a module package belongs to. Nil for standard library
+	// packages and if modules aren't used.
+	Module *Module
+	// Standard is true for standard library packages.
+	Standard bool
+}
+
+// Module is a module description reported by "go list".
+type Module struct {
+	// Dir is a directory holding module files.
+	Dir string
+	// Main is true for project's own module.
+	Main bool
+	// Path is a module path.
+	Path string
+	// Replace is a module that replaces this one, if any.
+	Replace *Module
+	// Version is a module version.
+	Version string
+}
+
+// Deps returns packages matched by main packages patterns from
+// configuration ("./..." by default) and all packages they depend on,
+// i.e. every package that is compiled into binaries built from project
+// placed in passed directory. Test-only dependencies aren't included.
+// Packages are listed for every configured platform with configured
+// build tags.
+func Deps(dir string) ([]*Package, error) {
+	cfg := configuration.Cfg.Golang
+
+	patterns := cfg.Mains
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	platforms := cfg.Platforms
+	if len(platforms) == 0 {
+		// Empty platform means current one.
+		platforms = []string{""}
+	}
+
+	packages := make([]*Package, 0)
+	seen := make(map[string]bool)
+
+	for _, platform := range platforms {
+		platformPackages, err := list(dir, platform, cfg.Tags, patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range platformPackages {
+			if seen[pkg.ImportPath+" "+pkg.Dir] {
+				continue
+			}
+
+			seen[pkg.ImportPath+" "+pkg.Dir] = true
+
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages, nil
+}
+
+// LinkedOnly returns true if report should be restricted to modules
+// providing packages compiled into binaries.
+func LinkedOnly() bool {
+	cfg := configuration.Cfg.Golang
+
+	return cfg.LinkedOnly || len(cfg.Mains) > 0 || len(cfg.Platforms) > 0 || len(cfg.Tags) > 0
+}
+
+// LinkedModules returns set of modules (in "path@version" form) that
+// provide packages compiled into binaries built from project placed in
+// passed directory. Both replaced and replacement modules are in set.
+func LinkedModules(dir string) (map[string]bool, error) {
+	packages, err := Deps(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make(map[string]bool)
+
+	for _, pkg := range packages {
+		if pkg.Module == nil || pkg.Module.Main {
+			continue
+		}
+
+		modules[pkg.Module.Path+"@"+pkg.Module.Version] = true
+
+		if pkg.Module.Replace != nil && pkg.Module.Replace.Version != "" {
+			modules[pkg.Module.Replace.Path+"@"+pkg.Module.Replace.Version] = true
+		}
+	}
+
+	return modules, nil
+}
+
+// CheckPlatform returns error if passed platform isn't in "GOOS/GOARCH"
+// form.
+func CheckPlatform(platform string) error {
+	parts := strings.Split(platform, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return errors.New("invalid platform '" + platform + "', should be in 'GOOS/GOARCH' form")
+	}
+
+	return nil
+}
+
+// Executes "go list" for passed platform.
+func list(dir string, platform string, tags []string, patterns []string) ([]*Package, error) {
+	args := []string{"list", "-e", "-deps", "-json"}
+	if len(tags) > 0 {
+		args = append(args, "-tags", strings.Join(tags, ","))
+	}
+
+	args = append(args, patterns...)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if platform != "" {
+		if err := CheckPlatform(platform); err != nil {
+			return nil, err
+		}
+
+		parts := strings.Split(platform, "/")
+		cmd.Env = append(cmd.Env, "GOOS="+parts[0], "GOARCH="+parts[1])
+	}
+
+	logger.Debug("Executing 'go " + strings.Join(args, " ") + "' in " + dir + " for platform '" + platform + "'")
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message != "" {
+			return nil, errors.New("go list failed: " + err.Error() + ": " + message)
+		}
+
+		return nil, errors.New("go list failed: " + err.Error())
+	}
+
+	// "go list -json" writes stream of JSON objects, one per package.
+	packages := make([]*Package, 0)
+	decoder := json.NewDecoder(&stdout)
+
+	for {
+		pkg := &Package{}
+
+		err := decoder.Decode(pkg)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.New("failed to parse go list output: " + err.Error())
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
@@ -2,15 +2,17 @@ package httpclient
 
 import (
 	// stdlib
+	"errors"
 	"io/ioutil"
-	"log"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	// local
-	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
 )
 
 const (
@@ -39,13 +41,36 @@ var (
 
 // Initialize initializes package.
 func Initialize() {
-	log.Println("Initializing HTTP client...")
+	logger.Debug("Initializing HTTP client...")
 
 	perDomainRequests = make(map[string]int)
 }
 
-// GET executes GET request and returns body.
+// GET executes GET request and returns body. Nil is returned if request
+// failed, response status isn't checked.
 func GET(request *http.Request) []byte {
+	_, body := execute(request)
+
+	return body
+}
+
+// Fetch executes GET request and returns body. Unlike GET it returns
+// error if response status isn't 200 OK.
+func Fetch(request *http.Request) ([]byte, error) {
+	status, body := execute(request)
+	if body == nil {
+		return nil, errors.New("request to " + request.URL.String() + " failed")
+	}
+
+	if status != http.StatusOK {
+		return nil, errors.New(request.URL.String() + " responded with status " + strconv.Itoa(status))
+	}
+
+	return body, nil
+}
+
+// Executes request and returns response status and body.
+func execute(request *http.Request) (int, []byte) {
 	for {
 		perDomainRequestsMutex.Lock()
 		currentlyRunning, found := perDomainRequests[request.URL.Host]
@@ -82,9 +107,7 @@ func GET(request *http.Request) []byte {
 		perDomainRequestsMutex.Unlock()
 	}()
 
-	if configuration.Cfg.Log.Debug {
-		log.Println("Executing request:", request.URL.String())
-	}
+	logger.Trace("Executing request:", request.URL.String())
 
 	var (
 		requestsCount = 0
@@ -93,15 +116,17 @@ func GET(request *http.Request) []byte {
 
 	for {
 		if requestsCount == 3 {
-			log.Printf("Failed to execute request %s: tried 3 times and got errors. Skipping.", request.URL.String())
-			return nil
+			logger.Warnf("Failed to execute request %s: tried 3 times and got errors. Skipping.", request.URL.String())
+			diagnostics.Warning(diagnostics.CodeHTTPRequestFailed, request.URL.String(), "", "tried 3 times and got errors")
+
+			return 0, nil
 		}
 
 		var err error
 
 		response, err = httpClient.Do(request)
 		if err != nil {
-			log.Printf("Failed to execute request %s: %s\n", request.URL.String(), err.Error())
+			logger.Debugf("Failed to execute request %s: %s", request.URL.String(), err.Error())
 			requestsCount++
 			time.Sleep(time.Second * 1)
 			continue
@@ -114,9 +139,11 @@ func GET(request *http.Request) []byte {
 	response.Body.Close()
 
 	if err1 != nil {
-		log.Printf("Failed to read response body %s: %s\n", request.URL.String(), err1.Error())
-		return nil
+		logger.Warnf("Failed to read response body %s: %s", request.URL.String(), err1.Error())
+		diagnostics.Warning(diagnostics.CodeHTTPBodyReadFailed, request.URL.String(), "", err1.Error())
+
+		return 0, nil
 	}
 
-	return respBody
+	return response.StatusCode, respBody
 }
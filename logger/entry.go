@@ -0,0 +1,108 @@
+package logger
+
+import (
+	// stdlib
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry is a logger with attached structured fields.
+type Entry struct {
+	fields Fields
+}
+
+// Error writes error message.
+func (e *Entry) Error(v ...interface{}) { write(LevelError, e.fields, fmt.Sprintln(v...)) }
+
+// Errorf writes formatted error message.
+func (e *Entry) Errorf(f string, v ...interface{}) { write(LevelError, e.fields, fmt.Sprintf(f, v...)) }
+
+// Warn writes warning message.
+func (e *Entry) Warn(v ...interface{}) { write(LevelWarn, e.fields, fmt.Sprintln(v...)) }
+
+// Warnf writes formatted warning message.
+func (e *Entry) Warnf(f string, v ...interface{}) { write(LevelWarn, e.fields, fmt.Sprintf(f, v...)) }
+
+// Info writes informational message.
+func (e *Entry) Info(v ...interface{}) { write(LevelInfo, e.fields, fmt.Sprintln(v...)) }
+
+// Infof writes formatted informational message.
+func (e *Entry) Infof(f string, v ...interface{}) { write(LevelInfo, e.fields, fmt.Sprintf(f, v...)) }
+
+// Debug writes debug message.
+func (e *Entry) Debug(v ...interface{}) { write(LevelDebug, e.fields, fmt.Sprintln(v...)) }
+
+// Debugf writes formatted debug message.
+func (e *Entry) Debugf(f string, v ...interface{}) { write(LevelDebug, e.fields, fmt.Sprintf(f, v...)) }
+
+// Trace writes trace message.
+func (e *Entry) Trace(v ...interface{}) { write(LevelTrace, e.fields, fmt.Sprintln(v...)) }
+
+// Tracef writes formatted trace message.
+func (e *Entry) Tracef(f string, v ...interface{}) { write(LevelTrace, e.fields, fmt.Sprintf(f, v...)) }
+
+// Writes log line if passed level is enabled.
+func write(lvl Level, fields Fields, message string) {
+	if !IsEnabled(lvl) {
+		return
+	}
+
+	writeLine(lvl, fields, message)
+}
+
+// Writes fatal log line and exits.
+func writeFatal(fields Fields, message string) {
+	writeLine(LevelError, fields, message)
+	os.Exit(1)
+}
+
+// Formats and writes log line regardless of configured level.
+func writeLine(lvl Level, fields Fields, message string) {
+	message = strings.TrimRight(message, "\n")
+	now := time.Now()
+
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	if format == FormatJSON {
+		line := make(map[string]interface{}, len(fields)+3)
+		for k, v := range fields {
+			line[k] = v
+		}
+
+		line["time"] = now.Format(time.RFC3339Nano)
+		line["level"] = lvl.String()
+		line["msg"] = message
+
+		data, err := json.Marshal(line)
+		if err != nil {
+			data, _ = json.Marshal(map[string]string{"time": now.Format(time.RFC3339Nano), "level": lvl.String(), "msg": message})
+		}
+
+		fmt.Fprintln(output, string(data))
+
+		return
+	}
+
+	// Text format: fields are appended as sorted key=value pairs.
+	var fieldsString string
+
+	if len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fieldsString += fmt.Sprintf(" %s=%v", k, fields[k])
+		}
+	}
+
+	fmt.Fprintf(output, "%s [%s] %s%s\n", now.Format("2006/01/02 15:04:05"), strings.ToUpper(lvl.String()), message, fieldsString)
+}
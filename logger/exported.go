@@ -0,0 +1,106 @@
+package logger
+
+import (
+	// stdlib
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	// FormatText is a human-readable log lines format. Default one.
+	FormatText = "text"
+	// FormatJSON is a JSON lines format suitable for log aggregation
+	// systems.
+	FormatJSON = "json"
+)
+
+var (
+	level  = LevelInfo
+	format = FormatText
+	quiet  bool
+
+	output      io.Writer = os.Stderr
+	outputMutex sync.Mutex
+)
+
+// Fields is a set of additional structured data attached to log line.
+type Fields map[string]interface{}
+
+// Configure sets logging level, format and quiet mode. Quiet mode
+// disables logging completely.
+func Configure(levelName string, formatName string, quietMode bool) error {
+	lvl, err := ParseLevel(levelName)
+	if err != nil {
+		return err
+	}
+
+	if formatName != FormatText && formatName != FormatJSON {
+		return errors.New("unknown log format '" + formatName + "', should be one of: text, json")
+	}
+
+	outputMutex.Lock()
+	level = lvl
+	format = formatName
+	quiet = quietMode
+	outputMutex.Unlock()
+
+	return nil
+}
+
+// IsEnabled returns true if messages with passed level will be written.
+func IsEnabled(lvl Level) bool {
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	return !quiet && lvl <= level
+}
+
+// WithFields returns entry which will attach passed fields to every
+// log line.
+func WithFields(fields Fields) *Entry {
+	return &Entry{fields: fields}
+}
+
+// Error writes error message.
+func Error(v ...interface{}) { write(LevelError, nil, fmt.Sprintln(v...)) }
+
+// Errorf writes formatted error message.
+func Errorf(f string, v ...interface{}) { write(LevelError, nil, fmt.Sprintf(f, v...)) }
+
+// Warn writes warning message.
+func Warn(v ...interface{}) { write(LevelWarn, nil, fmt.Sprintln(v...)) }
+
+// Warnf writes formatted warning message.
+func Warnf(f string, v ...interface{}) { write(LevelWarn, nil, fmt.Sprintf(f, v...)) }
+
+// Info writes informational message.
+func Info(v ...interface{}) { write(LevelInfo, nil, fmt.Sprintln(v...)) }
+
+// Infof writes formatted informational message.
+func Infof(f string, v ...interface{}) { write(LevelInfo, nil, fmt.Sprintf(f, v...)) }
+
+// Debug writes debug message.
+func Debug(v ...interface{}) { write(LevelDebug, nil, fmt.Sprintln(v...)) }
+
+// Debugf writes formatted debug message.
+func Debugf(f string, v ...interface{}) { write(LevelDebug, nil, fmt.Sprintf(f, v...)) }
+
+// Trace writes trace message.
+func Trace(v ...interface{}) { write(LevelTrace, nil, fmt.Sprintln(v...)) }
+
+// Tracef writes formatted trace message.
+func Tracef(f string, v ...interface{}) { write(LevelTrace, nil, fmt.Sprintf(f, v...)) }
+
+// Fatal writes error message and exits with non-zero code. Message is
+// written even in quiet mode as it is the only explanation of exit.
+func Fatal(v ...interface{}) {
+	writeFatal(nil, fmt.Sprintln(v...))
+}
+
+// Fatalf writes formatted error message and exits with non-zero code.
+func Fatalf(f string, v ...interface{}) {
+	writeFatal(nil, fmt.Sprintf(f, v...))
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	// stdlib
+	"errors"
+	"strings"
+)
+
+// Level is a logging level.
+type Level int
+
+const (
+	// LevelError is for errors only.
+	LevelError Level = iota
+	// LevelWarn is for warnings and errors.
+	LevelWarn
+	// LevelInfo is for general progress information. Default one.
+	LevelInfo
+	// LevelDebug is for information useful while debugging.
+	LevelDebug
+	// LevelTrace is for very verbose output like parsed structures
+	// and executed requests.
+	LevelTrace
+)
+
+var levelNames = map[Level]string{
+	LevelError: "error",
+	LevelWarn:  "warn",
+	LevelInfo:  "info",
+	LevelDebug: "debug",
+	LevelTrace: "trace",
+}
+
+// String returns level name.
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel returns level for passed name.
+func ParseLevel(name string) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	// "warning" is too common to not accept it.
+	if name == "warning" {
+		name = "warn"
+	}
+
+	for level, levelName := range levelNames {
+		if levelName == name {
+			return level, nil
+		}
+	}
+
+	return LevelInfo, errors.New("unknown log level '" + name + "', should be one of: error, warn, info, debug, trace")
+}
@@ -2,49 +2,245 @@ package csv
 
 import (
 	// stdlib
+	"bufio"
 	c "encoding/csv"
-	"log"
-	"os"
+	"errors"
+	"io"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/structs"
 )
 
+const (
+	// Quoting modes.
+	quoteMinimal = "minimal"
+	quoteAll     = "all"
+)
+
+// This structure represents single CSV column.
+type column struct {
+	header string
+	value  func(dep *structs.Dependency, s *settings) string
+}
+
 var (
-	headers = []string{"Module", "Version", "License", "Repository URL", "License URL", "Project", "Copyrights"}
+	// Available columns with default header labels.
+	columns = map[string]*column{
+		"module":  {"Module", func(dep *structs.Dependency, s *settings) string { return dep.Name }},
+		"version": {"Version", func(dep *structs.Dependency, s *settings) string { return dep.Version }},
+		"license": {"License", func(dep *structs.Dependency, s *settings) string { return dep.License.Name }},
+		"license_expression": {"License Expression", func(dep *structs.Dependency, s *settings) string {
+			return dep.License.Expression
+		}},
+		"repository_url": {"Repository URL", func(dep *structs.Dependency, s *settings) string { return dep.VCS.VCSPath }},
+		"license_url":    {"License URL", func(dep *structs.Dependency, s *settings) string { return dep.License.URL }},
+		"license_file":   {"License File", func(dep *structs.Dependency, s *settings) string { return dep.License.File }},
+		"confidence": {"Confidence", func(dep *structs.Dependency, s *settings) string {
+			if dep.License.Confidence == 0 {
+				return ""
+			}
+
+			return strconv.FormatFloat(float64(dep.License.Confidence), 'f', 2, 64)
+		}},
+		"detection_method": {"Detection Method", func(dep *structs.Dependency, s *settings) string { return dep.License.DetectionMethod }},
+		"project":          {"Project", func(dep *structs.Dependency, s *settings) string { return dep.Parent }},
+		"copyrights": {"Copyrights", func(dep *structs.Dependency, s *settings) string {
+			return strings.Join(dep.License.Copyrights, s.copyrightsSeparator)
+		}},
+		"notice_file":  {"NOTICE File", func(dep *structs.Dependency, s *settings) string { return dep.License.NoticeFile }},
+		"notice":       {"NOTICE", func(dep *structs.Dependency, s *settings) string { return dep.License.Notice }},
+		"checksum":     {"Checksum", func(dep *structs.Dependency, s *settings) string { return dep.Checksum }},
+		"verification": {"Verification", func(dep *structs.Dependency, s *settings) string { return dep.Verification }},
+		"nested_licenses": {"Nested Licenses", func(dep *structs.Dependency, s *settings) string {
+			nested := make([]string, 0, len(dep.License.Nested))
+			for _, license := range dep.License.Nested {
+				nested = append(nested, license.String())
+			}
+
+			return strings.Join(nested, ", ")
+		}},
+	}
+
+	// All available columns names, in order.
+	columnsNames = []string{"module", "version", "license", "license_expression", "confidence", "detection_method", "license_file",
+		"repository_url", "license_url", "project", "copyrights", "notice_file", "notice",
+		"nested_licenses", "checksum", "verification"}
+
+	// Columns written by default.
+	defaultColumns = []string{"module", "version", "license", "repository_url", "license_url", "project", "copyrights"}
 )
 
+// This structure holds CSV writing settings obtained from configuration.
+type settings struct {
+	columns             []string
+	copyrightsSeparator string
+	delimiter           rune
+	headers             []string
+	quote               string
+}
+
 // Responsible for pushing passed data into CSV file.
 type outputter struct{}
 
-func (o *outputter) Write(deps []*structs.Dependency, outFile string) {
-	log.Println("Got", strconv.Itoa(len(deps)), "dependencies to write")
+func (o *outputter) Write(deps []*structs.Dependency, w io.Writer) error {
+	logger.Info("Got", strconv.Itoa(len(deps)), "dependencies to write")
 
-	// Check if file exists and remove it if so.
-	if _, err := os.Stat(outFile); !os.IsNotExist(err) || err == nil {
-		os.Remove(outFile)
+	s, err := newSettings()
+	if err != nil {
+		return errors.New("invalid CSV output configuration: " + err.Error())
 	}
 
-	// Open file and create writer.
-	f, err := os.Create(outFile)
-	if err != nil {
-		log.Fatalln("Failed to open '"+outFile+"' for writing:", err.Error())
+	if err := write(w, deps, s); err != nil {
+		return errors.New("failed to write CSV report: " + err.Error())
 	}
 
-	writer := c.NewWriter(f)
-	writer.Comma = ';'
+	return nil
+}
 
-	// Write header first.
-	_ = writer.Write(headers)
+// Writes header and dependencies information.
+func write(w io.Writer, deps []*structs.Dependency, s *settings) error {
+	records := make([][]string, 0, len(deps)+1)
+	records = append(records, s.headers)
 
-	// Write dependencies information.
 	for _, dep := range deps {
-		_ = writer.Write([]string{dep.Name, dep.Version, dep.License.Name, dep.VCS.VCSPath, dep.License.URL, dep.Parent, strings.Join(dep.License.Copyrights, ",")})
+		record := make([]string, 0, len(s.columns))
+		for _, name := range s.columns {
+			record = append(record, columns[name].value(dep, s))
+		}
+
+		records = append(records, record)
 	}
 
-	writer.Flush()
+	if s.quote == quoteAll {
+		return writeQuoted(w, records, s.delimiter)
+	}
+
+	writer := c.NewWriter(w)
+	writer.Comma = s.delimiter
+
+	return writer.WriteAll(records)
+}
+
+// Writes records with every field quoted. Standard CSV writer quotes
+// only fields that require quoting.
+func writeQuoted(w io.Writer, records [][]string, delimiter rune) error {
+	bw := bufio.NewWriter(w)
+
+	for _, record := range records {
+		for idx, field := range record {
+			if idx > 0 {
+				_, _ = bw.WriteRune(delimiter)
+			}
+
+			_, _ = bw.WriteString(`"` + strings.Replace(field, `"`, `""`, -1) + `"`)
+		}
+
+		_, _ = bw.WriteString("\n")
+	}
+
+	return bw.Flush()
+}
+
+// Delimiter returns fields delimiter of configured CSV report.
+func Delimiter() (rune, error) {
+	s, err := newSettings()
+	if err != nil {
+		return 0, errors.New("invalid CSV output configuration: " + err.Error())
+	}
+
+	return s.delimiter, nil
+}
+
+// ColumnIndex returns index of column with passed name (e.g. "module")
+// in report's header. Both configured and default header labels are
+// recognized, so reports written with other settings can be read too.
+// -1 is returned if report has no such column.
+func ColumnIndex(header []string, name string) int {
+	col, found := columns[name]
+	if !found {
+		return -1
+	}
+
+	labels := []string{col.header}
+	if label, found := configuration.Cfg.Outputs.CSV.Headers[name]; found {
+		labels = append([]string{label}, labels...)
+	}
+
+	for _, label := range labels {
+		for idx, field := range header {
+			if strings.TrimSpace(field) == label {
+				return idx
+			}
+		}
+	}
+
+	return -1
+}
+
+// Creates settings from configuration, filling defaults.
+func newSettings() (*settings, error) {
+	cfg := configuration.Cfg.Outputs.CSV
+
+	s := &settings{
+		columns:             cfg.Columns,
+		copyrightsSeparator: cfg.CopyrightsSeparator,
+		delimiter:           ';',
+		quote:               cfg.Quote,
+	}
+
+	if len(s.columns) == 0 {
+		s.columns = defaultColumns
+	}
+
+	if s.copyrightsSeparator == "" {
+		s.copyrightsSeparator = ","
+	}
+
+	switch cfg.Delimiter {
+	case "":
+	case "tab", `\t`:
+		s.delimiter = '\t'
+	default:
+		delimiter, size := utf8.DecodeRuneInString(cfg.Delimiter)
+		if size != len(cfg.Delimiter) || delimiter == utf8.RuneError || delimiter == '"' || delimiter == '\r' || delimiter == '\n' {
+			return nil, errors.New("delimiter should be single character other than quote or line break, got '" + cfg.Delimiter + "'")
+		}
+
+		s.delimiter = delimiter
+	}
+
+	switch s.quote {
+	case "":
+		s.quote = quoteMinimal
+	case quoteMinimal, quoteAll:
+	default:
+		return nil, errors.New("unknown quoting mode '" + s.quote + "', should be 'minimal' or 'all'")
+	}
+
+	for _, name := range s.columns {
+		col, found := columns[name]
+		if !found {
+			return nil, errors.New("unknown column '" + name + "', should be one of: " + strings.Join(columnsNames, ", "))
+		}
+
+		header := col.header
+		if label, found := cfg.Headers[name]; found {
+			header = label
+		}
+
+		s.headers = append(s.headers, header)
+	}
+
+	for name := range cfg.Headers {
+		if _, found := columns[name]; !found {
+			return nil, errors.New("header label defined for unknown column '" + name + "'")
+		}
+	}
 
-	f.Close()
+	return s, nil
 }
@@ -0,0 +1,97 @@
+package csv
+
+import (
+	// stdlib
+	"bytes"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+func TestWrite(t *testing.T) {
+	deps := []*structs.Dependency{
+		{
+			Name:    "example.com/dep",
+			Version: "v1.0.0",
+			Parent:  "example.com/project",
+			License: structs.License{
+				Name:       "MIT",
+				Confidence: 0.987,
+				Copyrights: []string{"Copyright (c) 2018 John Doe", "Copyright (c) 2019 \"Jane\" Doe"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		section string
+		report  string
+	}{
+		{
+			name:    "default delimiter and copyrights separator",
+			section: "    columns: [module, version, license, copyrights]\n",
+			report: "Module;Version;License;Copyrights\n" +
+				"example.com/dep;v1.0.0;MIT;\"Copyright (c) 2018 John Doe,Copyright (c) 2019 \"\"Jane\"\" Doe\"\n",
+		},
+		{
+			name:    "tab delimiter and copyrights separator",
+			section: "    columns: [module, copyrights]\n    delimiter: tab\n    copyrights_separator: \" | \"\n",
+			report: "Module\tCopyrights\n" +
+				"example.com/dep\t\"Copyright (c) 2018 John Doe | Copyright (c) 2019 \"\"Jane\"\" Doe\"\n",
+		},
+		{
+			name:    "custom headers and quoting",
+			section: "    columns: [module, confidence, project]\n    delimiter: \",\"\n    quote: all\n    headers:\n      module: Name\n",
+			report:  "\"Name\",\"Confidence\",\"Project\"\n\"example.com/dep\",\"0.99\",\"example.com/project\"\n",
+		},
+		{
+			name:    "empty values",
+			section: "    columns: [module, license_expression, checksum]\n",
+			report:  "Module;License Expression;Checksum\nexample.com/dep;;\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration.InitializeForTest(t, "outputs:\n  csv:\n"+test.section)
+
+			var b bytes.Buffer
+			if err := (&outputter{}).Write(deps, &b); err != nil {
+				t.Fatalf("Write() returned error: %v", err)
+			}
+
+			if b.String() != test.report {
+				t.Errorf("Write() = %q, want %q", b.String(), test.report)
+			}
+		})
+	}
+}
+
+func TestNewSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		valid   bool
+	}{
+		{name: "defaults", section: "    quote: \"\"\n", valid: true},
+		{name: "single character delimiter", section: "    delimiter: \"|\"\n", valid: true},
+		{name: "non-ASCII delimiter", section: "    delimiter: \"§\"\n", valid: true},
+		{name: "long delimiter", section: "    delimiter: \"||\"\n"},
+		{name: "quote delimiter", section: "    delimiter: '\"'\n"},
+		{name: "unknown quoting mode", section: "    quote: none\n"},
+		{name: "unknown column", section: "    columns: [module, author]\n"},
+		{name: "header for unknown column", section: "    headers:\n      author: Author\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration.InitializeForTest(t, "outputs:\n  csv:\n"+test.section)
+
+			if _, err := newSettings(); (err == nil) != test.valid {
+				t.Errorf("newSettings() = %v, want valid %v", err, test.valid)
+			}
+		})
+	}
+}
@@ -2,33 +2,170 @@ package outputters
 
 import (
 	// stdlib
-	"log"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 
 	// local
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/outputters/csv"
+	"go.dev.pztrn.name/glp/outputters/external"
+	"go.dev.pztrn.name/glp/outputters/html"
+	"go.dev.pztrn.name/glp/outputters/notice"
 	"go.dev.pztrn.name/glp/outputters/outputinterface"
+	"go.dev.pztrn.name/glp/outputters/pdf"
+	"go.dev.pztrn.name/glp/outputters/template"
+	"go.dev.pztrn.name/glp/outputters/xlsx"
+	"go.dev.pztrn.name/glp/plugins"
 	"go.dev.pztrn.name/glp/structs"
 )
 
 var (
-	outputters map[string]outputinterface.Interface
+	outputters      = make(map[string]outputinterface.Interface)
+	outputtersMutex sync.RWMutex
 )
 
+// Initialize initializes package. Built-in outputters are registered
+// first, then external outputters found in PATH (executables named
+// "glp-output-<name>").
 func Initialize() {
-	log.Println("Initializing output providers")
+	logger.Debug("Initializing output providers")
 
-	outputters = make(map[string]outputinterface.Interface)
+	builtin := map[string]outputinterface.Interface{
+		"csv":             csv.Initialize(),
+		"html":            html.Initialize(),
+		"notice":          notice.Initialize(false),
+		"notice-markdown": notice.Initialize(true),
+		"pdf":             pdf.Initialize(),
+		"template":        template.Initialize(),
+		"xlsx":            xlsx.Initialize(),
+	}
+
+	for name, outputterIface := range builtin {
+		if IsRegistered(name) {
+			logger.Debug("Built-in outputter '" + name + "' is overridden by registered one")
+			continue
+		}
+
+		if err := Register(name, outputterIface); err != nil {
+			logger.Warn("Failed to register outputter:", err.Error())
+		}
+	}
 
-	csvIface := csv.Initialize()
-	outputters["csv"] = csvIface
+	for _, executable := range plugins.Discover(plugins.OutputterPrefix) {
+		if err := Register(executable.Name, external.Initialize(executable)); err != nil {
+			logger.Warn("Failed to register external outputter '"+executable.Path+"':", err.Error())
+		}
+	}
 }
 
-// Write pushes parsed data into outputter for writing.
-func Write(outputter string, filePath string, deps []*structs.Dependency) {
+// Register adds outputter with passed name, which can be used as output
+// format afterwards. Outputters registered before Initialize() call
+// take precedence over built-in outputters with same name.
+func Register(name string, outputter outputinterface.Interface) error {
+	if name == "" || strings.Contains(name, ":") {
+		return errors.New("invalid outputter name '" + name + "'")
+	}
+
+	outputtersMutex.Lock()
+	defer outputtersMutex.Unlock()
+
+	if _, found := outputters[name]; found {
+		return errors.New("outputter '" + name + "' is already registered")
+	}
+
+	outputters[name] = outputter
+
+	return nil
+}
+
+// StdoutPath is an output file path that means writing report to
+// standard output.
+const StdoutPath = "-"
+
+// Output describes single report that should be written.
+type Output struct {
+	// Format is an outputter name.
+	Format string
+	// Path is an output file path or StdoutPath.
+	Path string
+}
+
+// ParseOutput parses output defined as "format:path" pair.
+func ParseOutput(value string) (*Output, error) {
+	idx := strings.Index(value, ":")
+	if idx <= 0 || idx == len(value)-1 {
+		return nil, errors.New("output '" + value + "' should be defined as 'format:path'")
+	}
+
+	return &Output{Format: value[:idx], Path: value[idx+1:]}, nil
+}
+
+// IsRegistered returns true if outputter with passed name exists.
+func IsRegistered(outputter string) bool {
+	outputtersMutex.RLock()
+	_, found := outputters[outputter]
+	outputtersMutex.RUnlock()
+
+	return found
+}
+
+// Write pushes parsed data into outputter for writing. If filePath is
+// StdoutPath report will be written to standard output.
+// Files are written atomically: report is written into temporary file
+// in same directory which replaces destination file only if report was
+// written successfully, so previous report is kept intact on failures.
+func Write(outputter string, filePath string, deps []*structs.Dependency) error {
+	outputtersMutex.RLock()
 	outputterIface, found := outputters[outputter]
+	outputtersMutex.RUnlock()
+
 	if !found {
-		log.Fatalln("Failed to find outputter '" + outputter + "'!")
+		return errors.New("failed to find outputter '" + outputter + "'")
+	}
+
+	if filePath == StdoutPath {
+		return outputterIface.Write(deps, os.Stdout)
+	}
+
+	// Existing file permissions are preserved.
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(filePath); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return errors.New("failed to create temporary file for '" + filePath + "': " + err.Error())
+	}
+
+	tmpPath := f.Name()
+
+	if err := outputterIface.Write(deps, f); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return errors.New("failed to write '" + filePath + "': " + err.Error())
 	}
 
-	outputterIface.Write(deps, filePath)
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		logger.Warn("Failed to set permissions for '"+filePath+"':", err.Error())
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return errors.New("failed to replace '" + filePath + "': " + err.Error())
+	}
+
+	logger.Info("Report written to", filePath)
+
+	return nil
 }
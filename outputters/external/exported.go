@@ -0,0 +1,17 @@
+package external
+
+import (
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/outputinterface"
+	"go.dev.pztrn.name/glp/plugins"
+)
+
+// Initialize creates outputter that delegates writing to external
+// executable.
+func Initialize(executable *plugins.Executable) outputinterface.Interface {
+	logger.Debug("Initializing external outputter '" + executable.Name + "' (" + executable.Path + ")")
+
+	e := &outputter{executable: executable}
+	return outputinterface.Interface(e)
+}
@@ -0,0 +1,29 @@
+package external
+
+import (
+	// stdlib
+	"io"
+	"strconv"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/plugins"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// This structure is a request sent to external outputter on stdin.
+type request struct {
+	Dependencies []*structs.Dependency `json:"dependencies"`
+}
+
+// Responsible for writing report with external executable. Everything
+// executable writes to stdout is a report.
+type outputter struct {
+	executable *plugins.Executable
+}
+
+func (o *outputter) Write(deps []*structs.Dependency, w io.Writer) error {
+	logger.Info("Got", strconv.Itoa(len(deps)), "dependencies to write")
+
+	return o.executable.Stream(&request{Dependencies: deps}, w)
+}
@@ -0,0 +1,14 @@
+package html
+
+import (
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/outputinterface"
+)
+
+func Initialize() outputinterface.Interface {
+	logger.Debug("Initializing html outputter...")
+
+	h := &outputter{}
+	return outputinterface.Interface(h)
+}
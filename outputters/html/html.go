@@ -0,0 +1,211 @@
+package html
+
+import (
+	// stdlib
+	"errors"
+	ht "html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+const (
+	// License chart geometry.
+	chartBarHeight   = 24
+	chartBarMaxWidth = 340
+	chartLabelWidth  = 220
+	// Space for count placed after bar.
+	chartCountGap   = 6
+	chartCountWidth = 80
+)
+
+// This structure is a data passed to page template.
+type pageData struct {
+	ChartHeight     int
+	ChartLabelWidth int
+	ChartWidth      int
+	Dependencies    []*dependencyData
+	Generated       string
+	Licenses        []*licenseData
+	NeedsReview     int
+	Projects        []string
+	Unknown         int
+	Unverified      int
+}
+
+// This structure describes single license in summary.
+type licenseData struct {
+	BarWidth int
+	Count    int
+	CountX   int
+	Name     string
+	Percent  string
+	Unknown  bool
+	Y        int
+}
+
+// This structure describes single dependency row and details section.
+type dependencyData struct {
+	Checksum        string
+	Confidence      string
+	Copyrights      []string
+	DetectionMethod string
+	Expression      string
+	ID              string
+	License         string
+	LicenseFile     string
+	LicenseURL      string
+	Name            string
+	NeedsReview     bool
+	Nested          []*structs.NestedLicense
+	Notice          string
+	Parent          string
+	RepositoryURL   string
+	SourceURL       string
+	Unknown         bool
+	Unverified      bool
+	Verification    string
+	Version         string
+}
+
+// Responsible for writing self-contained HTML report.
+type outputter struct{}
+
+func (o *outputter) Write(deps []*structs.Dependency, w io.Writer) error {
+	logger.Info("Got", strconv.Itoa(len(deps)), "dependencies to write")
+
+	tpl, err := ht.New("report").Parse(pageTemplate)
+	if err != nil {
+		return errors.New("failed to parse HTML report template: " + err.Error())
+	}
+
+	if err := tpl.Execute(w, newPageData(deps)); err != nil {
+		return errors.New("failed to render HTML report: " + err.Error())
+	}
+
+	return nil
+}
+
+// Prepares data for page template.
+func newPageData(deps []*structs.Dependency) *pageData {
+	data := &pageData{Generated: time.Now().Format("2006-01-02 15:04:05 MST")}
+
+	counts := make(map[string]int)
+	projects := make(map[string]bool)
+
+	for idx, dep := range deps {
+		licenseName := dep.License.Name
+		if licenseName == "" {
+			licenseName = "Unknown"
+		}
+
+		counts[licenseName]++
+
+		if dep.Parent != "" {
+			for _, parent := range strings.Split(dep.Parent, ",") {
+				projects[parent] = true
+			}
+		}
+
+		d := &dependencyData{
+			Checksum:        dep.Checksum,
+			Copyrights:      dep.License.Copyrights,
+			ID:              "dep-" + strconv.Itoa(idx),
+			Confidence:      strconv.FormatFloat(float64(dep.License.Confidence), 'f', 2, 64),
+			DetectionMethod: dep.License.DetectionMethod,
+			Expression:      dep.License.Expression,
+			LicenseFile:     dep.License.File,
+			License:         licenseName,
+			LicenseURL:      dep.License.URL,
+			Name:            dep.Name,
+			NeedsReview:     licenseName == structs.LicenseNeedsReview,
+			Nested:          dep.License.Nested,
+			Notice:          dep.License.Notice,
+			Parent:          dep.Parent,
+			RepositoryURL:   dep.VCS.VCSPath,
+			SourceURL:       formatDirURL(dep.VCS.SourceURLDirTemplate, dep.VCS.Dir),
+			Unknown:         licenseName == "Unknown",
+			Unverified:      dep.Verification == structs.VerificationMismatch || dep.Verification == structs.VerificationFailed,
+			Verification:    dep.Verification,
+			Version:         dep.Version,
+		}
+
+		if d.Unknown {
+			data.Unknown++
+		}
+
+		if d.NeedsReview {
+			data.NeedsReview++
+		}
+
+		if d.Unverified {
+			data.Unverified++
+		}
+
+		data.Dependencies = append(data.Dependencies, d)
+	}
+
+	sort.SliceStable(data.Dependencies, func(i, j int) bool { return data.Dependencies[i].Name < data.Dependencies[j].Name })
+
+	for project := range projects {
+		data.Projects = append(data.Projects, project)
+	}
+
+	sort.Strings(data.Projects)
+
+	data.Licenses = newLicensesData(counts, len(deps))
+	data.ChartHeight = len(data.Licenses) * chartBarHeight
+	data.ChartLabelWidth = chartLabelWidth
+	data.ChartWidth = chartLabelWidth + chartBarMaxWidth + chartCountWidth
+
+	return data
+}
+
+// Prepares licenses summary sorted by usage count.
+func newLicensesData(counts map[string]int, total int) []*licenseData {
+	licenses := make([]*licenseData, 0, len(counts))
+	maxCount := 0
+
+	for name, count := range counts {
+		licenses = append(licenses, &licenseData{Name: name, Count: count, Unknown: name == "Unknown"})
+
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	sort.Slice(licenses, func(i, j int) bool {
+		if licenses[i].Count == licenses[j].Count {
+			return licenses[i].Name < licenses[j].Name
+		}
+
+		return licenses[i].Count > licenses[j].Count
+	})
+
+	for idx, license := range licenses {
+		license.Y = idx * chartBarHeight
+		license.BarWidth = license.Count * chartBarMaxWidth / maxCount
+		license.CountX = chartLabelWidth + license.BarWidth + chartCountGap
+		license.Percent = strconv.FormatFloat(float64(license.Count)*100/float64(total), 'f', 1, 64)
+	}
+
+	return licenses
+}
+
+// Returns URL to dependency's root directory built from source
+// directory template. Dependency might be placed in repository
+// subdirectory.
+func formatDirURL(template string, dir string) string {
+	var slashDir string
+	if dir != "" {
+		slashDir = "/" + dir
+	}
+
+	return strings.NewReplacer("{dir}", dir, "{/dir}", slashDir).Replace(template)
+}
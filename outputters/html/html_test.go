@@ -0,0 +1,51 @@
+package html
+
+import (
+	// stdlib
+	"bytes"
+	"strings"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/structs"
+)
+
+func TestFormatDirURL(t *testing.T) {
+	tests := []struct {
+		template string
+		dir      string
+		url      string
+	}{
+		{"https://github.com/user/repo/tree/v1.0.0{/dir}", "", "https://github.com/user/repo/tree/v1.0.0"},
+		{"https://github.com/user/repo/tree/sub/v1.0.0{/dir}", "sub", "https://github.com/user/repo/tree/sub/v1.0.0/sub"},
+		{"https://example.com/repo/src/{dir}", "sub", "https://example.com/repo/src/sub"},
+		{"", "sub", ""},
+	}
+
+	for _, test := range tests {
+		if url := formatDirURL(test.template, test.dir); url != test.url {
+			t.Errorf("formatDirURL(%q, %q) = %q, want %q", test.template, test.dir, url, test.url)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	deps := []*structs.Dependency{
+		{Name: "example.com/a", Version: "v1.0.0", License: structs.License{Name: "MIT"}},
+		{Name: "example.com/b", Version: "v1.0.0", License: structs.License{Name: "MIT"}},
+		{Name: "example.com/c", Version: "v1.0.0"},
+	}
+
+	var buf bytes.Buffer
+	if err := (&outputter{}).Write(deps, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	page := buf.String()
+
+	for _, expected := range []string{`<rect x="220" y="0" width="340"`, `<text x="566" y="0" dy="14">2 (66.7%)</text>`, `<rect x="220" y="24" width="170" height="18" class="unknown">`, "1 with unknown license"} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("report doesn't contain %q", expected)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package html
+
+// Template of HTML report. Everything (styles, scripts and charts) is
+// inlined, so report can be opened without network access.
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Third party dependencies report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1, h2 { font-weight: normal; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; cursor: pointer; user-select: none; }
+th.sorted-asc::after { content: " \25B2"; }
+th.sorted-desc::after { content: " \25BC"; }
+tr.unknown td, .unknown { background: #fdd; }
+tr.review td, .review { background: #ffe9b3; }
+.controls { margin: 1em 0; }
+.controls input { width: 30em; padding: 4px; }
+.controls select { padding: 4px; }
+.summary text { font-size: 13px; }
+.summary rect { fill: #4a7ebb; }
+.summary rect.unknown { fill: #d9534f; }
+.details { border: 1px solid #ccc; margin: 1em 0; padding: 0.5em 1em; }
+.details h3 { margin: 0.3em 0; }
+.muted { color: #777; }
+</style>
+</head>
+<body>
+<h1>Third party dependencies report</h1>
+<p class="muted">Generated {{ .Generated }} for {{ range $idx, $p := .Projects }}{{ if $idx }}, {{ end }}{{ $p }}{{ end }}.</p>
+<p>{{ len .Dependencies }} dependencies, {{ len .Licenses }} licenses{{ if .Unknown }}, <span class="unknown">{{ .Unknown }} with unknown license</span>{{ end }}{{ if .NeedsReview }}, <span class="review">{{ .NeedsReview }} need license review</span>{{ end }}{{ if .Unverified }}, <span class="unknown">{{ .Unverified }} failed checksum verification</span>{{ end }}.</p>
+
+<h2>Licenses</h2>
+<svg class="summary" width="{{ .ChartWidth }}" height="{{ .ChartHeight }}" xmlns="http://www.w3.org/2000/svg">
+{{ range .Licenses }}<g>
+<text x="0" y="{{ .Y }}" dy="16">{{ .Name }}</text>
+<rect x="{{ $.ChartLabelWidth }}" y="{{ .Y }}" width="{{ .BarWidth }}" height="18"{{ if .Unknown }} class="unknown"{{ end }}></rect>
+<text x="{{ .CountX }}" y="{{ .Y }}" dy="14">{{ .Count }} ({{ .Percent }}%)</text>
+</g>
+{{ end }}</svg>
+
+<h2>Dependencies</h2>
+<div class="controls">
+<input id="filter" type="search" placeholder="Filter by any column...">
+<select id="license-filter">
+<option value="">All licenses</option>
+{{ range .Licenses }}<option value="{{ .Name }}">{{ .Name }} ({{ .Count }})</option>
+{{ end }}</select>
+</div>
+<table id="dependencies">
+<thead><tr><th>Module</th><th>Version</th><th>License</th><th>Project</th><th>Repository</th></tr></thead>
+<tbody>
+{{ range .Dependencies }}<tr data-license="{{ .License }}"{{ if or .Unknown .Unverified }} class="unknown"{{ else if .NeedsReview }} class="review"{{ end }}>
+<td><a href="#{{ .ID }}">{{ .Name }}</a></td>
+<td>{{ .Version }}</td>
+<td>{{ if .LicenseURL }}<a href="{{ .LicenseURL }}">{{ .License }}</a>{{ else }}{{ .License }}{{ end }}</td>
+<td>{{ .Parent }}</td>
+<td>{{ if .RepositoryURL }}<a href="{{ .RepositoryURL }}">{{ .RepositoryURL }}</a>{{ end }}</td>
+</tr>
+{{ end }}</tbody>
+</table>
+
+<h2>Details</h2>
+{{ range .Dependencies }}<div class="details{{ if or .Unknown .Unverified }} unknown{{ else if .NeedsReview }} review{{ end }}" id="{{ .ID }}">
+<h3>{{ .Name }} {{ .Version }}</h3>
+<p>License: <b>{{ .License }}</b>{{ if .LicenseURL }} (<a href="{{ .LicenseURL }}">license file</a>){{ end }}</p>
+{{ if and .Expression (ne .Expression .License) }}<p>License expression: {{ .Expression }}</p>{{ end }}
+{{ if .LicenseFile }}<p>Detected from {{ .LicenseFile }} ({{ .DetectionMethod }}) with confidence {{ .Confidence }}.</p>{{ end }}
+{{ if .Checksum }}<p>Checksum: {{ .Checksum }}{{ if .Verification }} (verification: <b>{{ .Verification }}</b>){{ end }}</p>{{ end }}
+{{ if .SourceURL }}<p>Sources: <a href="{{ .SourceURL }}">{{ .SourceURL }}</a></p>{{ end }}
+{{ if .RepositoryURL }}<p>Repository: <a href="{{ .RepositoryURL }}">{{ .RepositoryURL }}</a></p>{{ end }}
+<p>Project: {{ .Parent }}</p>
+{{ if .Copyrights }}<p>Copyrights:</p>
+<ul>{{ range .Copyrights }}<li>{{ . }}</li>{{ end }}</ul>{{ else }}<p class="muted">No copyrights found.</p>{{ end }}
+{{ if .Nested }}<p>Subdirectories with own licenses:</p>
+<ul>{{ range .Nested }}<li>{{ .Path }}: <b>{{ .Expression }}</b> ({{ .File }}{{ if .Usage }}, {{ .Usage }}{{ end }})</li>{{ end }}</ul>{{ end }}
+{{ if .Notice }}<details><summary>NOTICE</summary><pre>{{ .Notice }}</pre></details>{{ end }}
+</div>
+{{ end }}
+
+<script>
+(function() {
+  var table = document.getElementById("dependencies");
+  var body = table.tBodies[0];
+  var filter = document.getElementById("filter");
+  var licenseFilter = document.getElementById("license-filter");
+
+  function applyFilters() {
+    var text = filter.value.toLowerCase();
+    var license = licenseFilter.value;
+
+    Array.prototype.forEach.call(body.rows, function(row) {
+      var visible = row.textContent.toLowerCase().indexOf(text) !== -1 &&
+        (license === "" || row.getAttribute("data-license") === license);
+      row.style.display = visible ? "" : "none";
+    });
+  }
+
+  filter.addEventListener("input", applyFilters);
+  licenseFilter.addEventListener("change", applyFilters);
+
+  Array.prototype.forEach.call(table.tHead.rows[0].cells, function(header, column) {
+    header.addEventListener("click", function() {
+      var ascending = !header.classList.contains("sorted-asc");
+
+      Array.prototype.forEach.call(table.tHead.rows[0].cells, function(h) {
+        h.classList.remove("sorted-asc", "sorted-desc");
+      });
+      header.classList.add(ascending ? "sorted-asc" : "sorted-desc");
+
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function(a, b) {
+        var result = a.cells[column].textContent.localeCompare(b.cells[column].textContent, undefined, {numeric: true});
+        return ascending ? result : -result;
+      });
+      rows.forEach(function(row) { body.appendChild(row); });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,164 @@
+package licensetext
+
+import (
+	// stdlib
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/spdx"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Text is a license text shared by one or more dependencies. Most
+// licenses (like Apache-2.0) have same text for every dependency, so
+// it is written once.
+type Text struct {
+	// Text is a license text.
+	Text string
+	// Users is a list of dependencies using text, nested licenses are
+	// followed by subdirectory path.
+	Users []string
+	// Copyrights is a list of unique copyrights of users.
+	Copyrights []string
+}
+
+// Collect returns license texts of passed dependencies in order of
+// appearance. Identical texts are returned once. Texts of nested
+// licenses which packages are compiled into binaries are included as
+// they require attribution too.
+func Collect(deps []*structs.Dependency) []*Text {
+	texts := make(map[string]*Text)
+	result := make([]*Text, 0)
+
+	add := func(dep *structs.Dependency, file string, user string, copyrights []string) {
+		text := Read(dep, file)
+		if text == "" {
+			return
+		}
+
+		t, found := texts[text]
+		if !found {
+			t = &Text{Text: text}
+			texts[text] = t
+			result = append(result, t)
+		}
+
+		t.Users = append(t.Users, user)
+
+		for _, copyright := range copyrights {
+			if !contains(t.Copyrights, copyright) {
+				t.Copyrights = append(t.Copyrights, copyright)
+			}
+		}
+	}
+
+	for _, dep := range deps {
+		for _, file := range Files(dep) {
+			add(dep, file, dep.Name+" "+dep.Version, dep.License.Copyrights)
+		}
+
+		for _, nested := range LinkedNested(dep) {
+			add(dep, nested.File, dep.Name+" "+dep.Version+" ("+nested.Path+")", nested.Copyrights)
+		}
+	}
+
+	return result
+}
+
+// Name returns license name dependency is listed under: concluded
+// license or, if dependency is licensed under several licenses at once
+// (e.g. "Apache-2.0 AND BSD-3-Clause"), license expression.
+func Name(dep *structs.Dependency) string {
+	if expression, err := spdx.Parse(dep.License.Expression); err == nil && len(expression.Licenses()) > 1 && expression.Alternatives() == nil {
+		return expression.String()
+	}
+
+	if dep.License.Name == "" {
+		return "Unknown"
+	}
+
+	return dep.License.Name
+}
+
+// Files returns license files which texts should be reproduced for
+// dependency: concluded license's file and, if dependency is licensed
+// under several licenses at once, files every other license was
+// matched in. For choice of licenses only concluded one is used.
+func Files(dep *structs.Dependency) []string {
+	files := make([]string, 0, 1)
+
+	if dep.License.File != "" {
+		files = append(files, dep.License.File)
+	}
+
+	expression, err := spdx.Parse(dep.License.Expression)
+	if err != nil || expression.Alternatives() != nil {
+		return files
+	}
+
+	// Candidates are sorted by confidence, so the most confidently
+	// matched file is used for every license.
+	for _, license := range expression.Licenses() {
+		for _, candidate := range dep.License.Candidates {
+			if candidate.Name == license && candidate.File != "" {
+				if !contains(files, candidate.File) {
+					files = append(files, candidate.File)
+				}
+
+				break
+			}
+		}
+	}
+
+	return files
+}
+
+// LinkedNested returns dependency's nested licenses which packages are
+// (or might be) compiled into binaries. Bundled code that isn't
+// compiled into binaries requires no attribution.
+func LinkedNested(dep *structs.Dependency) []*structs.NestedLicense {
+	nested := make([]*structs.NestedLicense, 0, len(dep.License.Nested))
+
+	for _, license := range dep.License.Nested {
+		if license.Usage != structs.UsageNotLinked {
+			nested = append(nested, license)
+		}
+	}
+
+	return nested
+}
+
+// Read reads license text from passed file. File path is relative to
+// dependency's local path. Empty string is returned if text cannot be
+// read.
+func Read(dep *structs.Dependency, file string) string {
+	if file == "" || dep.LocalPath == "" {
+		return ""
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dep.LocalPath, filepath.FromSlash(file)))
+	if err != nil {
+		logger.Warn("Failed to read license text for", dep.Name+":", err.Error())
+		diagnostics.DependencyWarning(dep, diagnostics.CodeLicenseFileReadFailed, "license text cannot be read: "+err.Error())
+
+		return ""
+	}
+
+	// Only empty lines are trimmed as leading spaces are part of formatting.
+	return strings.TrimRight(strings.Trim(strings.Replace(string(data), "\r\n", "\n", -1), "\n"), " \t\n")
+}
+
+// Returns true if slice contains passed string.
+func contains(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,162 @@
+package licensetext
+
+import (
+	// stdlib
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Creates dependency directory with passed files.
+func createDependency(t *testing.T, name string, files map[string]string) *structs.Dependency {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for file, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &structs.Dependency{Name: name, Version: "v1.0.0", LocalPath: dir}
+}
+
+func TestCollect(t *testing.T) {
+	first := createDependency(t, "example.com/first", map[string]string{
+		"LICENSE":               "\r\nMIT License\r\n\r\n  Permission is hereby granted\r\n\r\n",
+		"third_party/x/COPYING": "BSD License\n",
+		"testdata/LICENSE":      "GPL\n",
+	})
+	first.License = structs.License{
+		Name:       "MIT",
+		File:       "LICENSE",
+		Copyrights: []string{"Copyright (c) 2018 John Doe"},
+		Nested: []*structs.NestedLicense{
+			{Path: "third_party/x", File: "third_party/x/COPYING", Usage: structs.UsageLinked, Copyrights: []string{"Copyright (c) 2010 X Authors"}},
+			{Path: "testdata", File: "testdata/LICENSE", Usage: structs.UsageNotLinked},
+		},
+	}
+
+	second := createDependency(t, "example.com/second", map[string]string{"LICENSE.txt": "MIT License\n\n  Permission is hereby granted\n"})
+	second.License = structs.License{
+		Name:       "MIT",
+		File:       "LICENSE.txt",
+		Copyrights: []string{"Copyright (c) 2018 John Doe", "Copyright (c) 2019 Jane Doe"},
+	}
+
+	missing := &structs.Dependency{Name: "example.com/missing", Version: "v1.0.0", LocalPath: second.LocalPath}
+	missing.License = structs.License{Name: "MIT", File: "COPYING"}
+
+	texts := Collect([]*structs.Dependency{first, second, missing})
+
+	want := []*Text{
+		{
+			Text:       "MIT License\n\n  Permission is hereby granted",
+			Users:      []string{"example.com/first v1.0.0", "example.com/second v1.0.0"},
+			Copyrights: []string{"Copyright (c) 2018 John Doe", "Copyright (c) 2019 Jane Doe"},
+		},
+		{
+			Text:       "BSD License",
+			Users:      []string{"example.com/first v1.0.0 (third_party/x)"},
+			Copyrights: []string{"Copyright (c) 2010 X Authors"},
+		},
+	}
+
+	if !reflect.DeepEqual(texts, want) {
+		for _, text := range texts {
+			t.Logf("%+v", text)
+		}
+
+		t.Errorf("Collect() returned unexpected texts")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	candidates := []*structs.LicenseCandidate{
+		{Name: "Apache-2.0", File: "LICENSE", Confidence: 0.99},
+		{Name: "BSD-3-Clause", File: "LICENSE-BSD", Confidence: 0.95},
+		{Name: "BSD-3-Clause", File: "README.md", Confidence: 0.8},
+		{Name: "MIT", File: "LICENSE-MIT", Confidence: 0.9},
+	}
+
+	tests := []struct {
+		name    string
+		license structs.License
+		files   []string
+		group   string
+	}{
+		{
+			name:    "single license",
+			license: structs.License{Name: "Apache-2.0", Expression: "Apache-2.0", File: "LICENSE", Candidates: candidates},
+			files:   []string{"LICENSE"},
+			group:   "Apache-2.0",
+		},
+		{
+			name:    "all licenses apply",
+			license: structs.License{Name: "Apache-2.0", Expression: "Apache-2.0 AND BSD-3-Clause AND MIT", File: "LICENSE", Candidates: candidates},
+			files:   []string{"LICENSE", "LICENSE-BSD", "LICENSE-MIT"},
+			group:   "Apache-2.0 AND BSD-3-Clause AND MIT",
+		},
+		{
+			name:    "choice of licenses",
+			license: structs.License{Name: "MIT", Expression: "Apache-2.0 OR MIT", File: "LICENSE-MIT", Candidates: candidates},
+			files:   []string{"LICENSE-MIT"},
+			group:   "MIT",
+		},
+		{
+			name:    "choice with required license",
+			license: structs.License{Name: "Apache-2.0", Expression: "(Apache-2.0 OR MIT) AND BSD-3-Clause", File: "LICENSE", Candidates: candidates},
+			files:   []string{"LICENSE", "LICENSE-MIT", "LICENSE-BSD"},
+			group:   "(Apache-2.0 OR MIT) AND BSD-3-Clause",
+		},
+		{
+			name:    "unknown license",
+			license: structs.License{},
+			files:   []string{},
+			group:   "Unknown",
+		},
+	}
+
+	for _, test := range tests {
+		dep := &structs.Dependency{Name: "example.com/dep", License: test.license}
+
+		if files := Files(dep); !reflect.DeepEqual(files, test.files) {
+			t.Errorf("%s: Files() = %q, want %q", test.name, files, test.files)
+		}
+
+		if group := Name(dep); group != test.group {
+			t.Errorf("%s: Name() = %q, want %q", test.name, group, test.group)
+		}
+	}
+}
+
+func TestCollectAllLicenses(t *testing.T) {
+	dep := createDependency(t, "example.com/bundle", map[string]string{"LICENSE": "Apache License\n", "COPYING": "BSD License\n"})
+	dep.License = structs.License{
+		Name:       "Apache-2.0",
+		Expression: "Apache-2.0 AND BSD-3-Clause",
+		File:       "LICENSE",
+		Candidates: []*structs.LicenseCandidate{{Name: "Apache-2.0", File: "LICENSE"}, {Name: "BSD-3-Clause", File: "COPYING"}},
+	}
+
+	texts := make([]string, 0)
+	for _, text := range Collect([]*structs.Dependency{dep}) {
+		texts = append(texts, text.Text)
+	}
+
+	if want := []string{"Apache License", "BSD License"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("Collect() texts = %q, want %q", texts, want)
+	}
+}
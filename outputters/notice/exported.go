@@ -0,0 +1,16 @@
+package notice
+
+import (
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/outputinterface"
+)
+
+// Initialize creates notices outputter. If markdown is true - notices
+// will be written in Markdown, otherwise as plain text.
+func Initialize(markdown bool) outputinterface.Interface {
+	logger.Debug("Initializing notice outputter...")
+
+	n := &outputter{markdown: markdown}
+	return outputinterface.Interface(n)
+}
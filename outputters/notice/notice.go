@@ -0,0 +1,206 @@
+package notice
+
+import (
+	// stdlib
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/licensetext"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// This structure represents dependencies licensed under same license.
+type licenseGroup struct {
+	name  string
+	deps  []*structs.Dependency
+	texts []*licensetext.Text
+}
+
+// Responsible for writing third party attribution file.
+type outputter struct {
+	markdown bool
+}
+
+func (o *outputter) Write(deps []*structs.Dependency, out io.Writer) error {
+	logger.Info("Got", strconv.Itoa(len(deps)), "dependencies to write")
+
+	w := bufio.NewWriter(out)
+	groups := groupByLicense(deps)
+
+	if o.markdown {
+		writeMarkdown(w, groups)
+	} else {
+		writeText(w, groups)
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.New("failed to write notices file: " + err.Error())
+	}
+
+	return nil
+}
+
+// Groups dependencies by license, groups are sorted by license name
+// and dependencies within group are sorted by name. Dependencies
+// licensed under several licenses at once are grouped by expression.
+func groupByLicense(deps []*structs.Dependency) []*licenseGroup {
+	groupsMap := make(map[string]*licenseGroup)
+
+	for _, dep := range deps {
+		name := licensetext.Name(dep)
+
+		group, found := groupsMap[name]
+		if !found {
+			group = &licenseGroup{name: name}
+			groupsMap[name] = group
+		}
+
+		group.deps = append(group.deps, dep)
+	}
+
+	groups := make([]*licenseGroup, 0, len(groupsMap))
+
+	for _, group := range groupsMap {
+		sort.SliceStable(group.deps, func(i, j int) bool { return group.deps[i].Name < group.deps[j].Name })
+
+		group.texts = licensetext.Collect(group.deps)
+
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].name < groups[j].name })
+
+	return groups
+}
+
+// Returns repository URL for dependency.
+func repositoryURL(dep *structs.Dependency) string {
+	if dep.VCS.VCSPath != "" {
+		return dep.VCS.VCSPath
+	}
+
+	return dep.URL
+}
+
+// Writes notices as plain text.
+func writeText(w *bufio.Writer, groups []*licenseGroup) {
+	separator := strings.Repeat("=", 80) + "\n"
+
+	_, _ = w.WriteString("THIRD PARTY NOTICES\n\n")
+	_, _ = w.WriteString("This software uses following third party components. Their licenses and\ncopyright notices are reproduced below, grouped by license.\n\n")
+
+	for _, group := range groups {
+		_, _ = w.WriteString("  " + group.name + ": " + strconv.Itoa(len(group.deps)) + "\n")
+	}
+
+	for _, group := range groups {
+		_, _ = w.WriteString("\n" + separator + group.name + "\n" + separator + "\n")
+
+		for _, dep := range group.deps {
+			_, _ = w.WriteString(dep.Name + " " + dep.Version + "\n")
+
+			if url := repositoryURL(dep); url != "" {
+				_, _ = w.WriteString("  Repository: " + url + "\n")
+			}
+
+			for _, copyright := range dep.License.Copyrights {
+				_, _ = w.WriteString("  " + copyright + "\n")
+			}
+
+			for _, nested := range licensetext.LinkedNested(dep) {
+				_, _ = w.WriteString("  Contains " + nested.Path + " licensed under " + nested.Expression + "\n")
+
+				for _, copyright := range nested.Copyrights {
+					_, _ = w.WriteString("    " + copyright + "\n")
+				}
+			}
+		}
+
+		for _, lt := range group.texts {
+			_, _ = w.WriteString("\n" + strings.Repeat("-", 80) + "\n")
+			_, _ = w.WriteString("License text for: " + strings.Join(lt.Users, ", ") + "\n\n")
+			_, _ = w.WriteString(lt.Text + "\n")
+		}
+
+		for _, dep := range group.deps {
+			if dep.License.Notice == "" {
+				continue
+			}
+
+			_, _ = w.WriteString("\n" + strings.Repeat("-", 80) + "\n")
+			_, _ = w.WriteString("NOTICE file for: " + dep.Name + " " + dep.Version + "\n\n")
+			_, _ = w.WriteString(dep.License.Notice + "\n")
+		}
+	}
+}
+
+// Writes notices as Markdown.
+func writeMarkdown(w *bufio.Writer, groups []*licenseGroup) {
+	_, _ = w.WriteString("# Third party notices\n\n")
+	_, _ = w.WriteString("This software uses following third party components. Their licenses and copyright notices are reproduced below, grouped by license.\n\n")
+
+	for _, group := range groups {
+		_, _ = w.WriteString("* [" + group.name + "](#" + anchor(group.name) + "): " + strconv.Itoa(len(group.deps)) + "\n")
+	}
+
+	for _, group := range groups {
+		_, _ = w.WriteString("\n## " + group.name + "\n\n")
+
+		for _, dep := range group.deps {
+			line := "* **" + dep.Name + "** " + dep.Version
+			if url := repositoryURL(dep); url != "" {
+				line += " (<" + url + ">)"
+			}
+
+			_, _ = w.WriteString(line + "\n")
+
+			for _, copyright := range dep.License.Copyrights {
+				_, _ = w.WriteString("  * " + copyright + "\n")
+			}
+
+			for _, nested := range licensetext.LinkedNested(dep) {
+				_, _ = w.WriteString("  * Contains `" + nested.Path + "` licensed under " + nested.Expression + "\n")
+
+				for _, copyright := range nested.Copyrights {
+					_, _ = w.WriteString("    * " + copyright + "\n")
+				}
+			}
+		}
+
+		for _, lt := range group.texts {
+			_, _ = w.WriteString("\n### License text for " + strings.Join(lt.Users, ", ") + "\n\n")
+			_, _ = w.WriteString("```text\n" + strings.Replace(lt.Text, "```", "` ` `", -1) + "\n```\n")
+		}
+
+		for _, dep := range group.deps {
+			if dep.License.Notice == "" {
+				continue
+			}
+
+			_, _ = w.WriteString("\n### NOTICE file for " + dep.Name + " " + dep.Version + "\n\n")
+			_, _ = w.WriteString("```text\n" + strings.Replace(dep.License.Notice, "```", "` ` `", -1) + "\n```\n")
+		}
+	}
+}
+
+// Returns GitHub-compatible heading anchor.
+func anchor(heading string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+
+	return b.String()
+}
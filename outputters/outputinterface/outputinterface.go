@@ -1,11 +1,16 @@
 package outputinterface
 
 import (
+	// stdlib
+	"io"
+
 	// local
 	"go.dev.pztrn.name/glp/structs"
 )
 
 // Interface is a generic output writer interface.
 type Interface interface {
-	Write(deps []*structs.Dependency, outFile string)
+	// Write writes passed dependencies into writer. Returned error
+	// means that report wasn't written or is incomplete.
+	Write(deps []*structs.Dependency, w io.Writer) error
 }
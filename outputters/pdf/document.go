@@ -0,0 +1,170 @@
+package pdf
+
+import (
+	// stdlib
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A4 page size in points.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// This structure represents single page. Content is a PDF content
+// stream with drawing operators.
+type page struct {
+	content bytes.Buffer
+}
+
+// This structure represents PDF document.
+type document struct {
+	created time.Time
+	pages   []*page
+	title   string
+}
+
+// Adds new page to document.
+func (d *document) addPage() *page {
+	p := &page{}
+	d.pages = append(d.pages, p)
+
+	return p
+}
+
+// Draws text with baseline starting at passed coordinates.
+func (p *page) text(f *font, size float64, x float64, y float64, s string) {
+	p.content.WriteString("BT /" + f.resource + " " + number(size) + " Tf " + number(x) + " " + number(y) + " Td (")
+	p.content.Write(escape(encode(s)))
+	p.content.WriteString(") Tj ET\n")
+}
+
+// Draws line.
+func (p *page) line(x1 float64, y1 float64, x2 float64, y2 float64, width float64) {
+	p.content.WriteString(number(width) + " w " + number(x1) + " " + number(y1) + " m " + number(x2) + " " + number(y2) + " l S\n")
+}
+
+// Draws filled rectangle with passed gray level (0 is black, 1 is white).
+func (p *page) fillRect(x float64, y float64, width float64, height float64, gray float64) {
+	p.content.WriteString(number(gray) + " g " + number(x) + " " + number(y) + " " + number(width) + " " + number(height) + " re f 0 g\n")
+}
+
+// Writes document into passed writer.
+// Objects layout: 1 - catalog, 2 - pages tree, 3 - info, then fonts,
+// then page and it's content stream for every page.
+func (d *document) write(w io.Writer) error {
+	out := &countingWriter{w: w}
+	offsets := make([]int64, 0)
+
+	fontsStart := 4
+	pagesStart := fontsStart + len(fonts)
+
+	object := func(body string) {
+		offsets = append(offsets, out.count)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	fmt.Fprint(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, 0, len(d.pages))
+	for idx := range d.pages {
+		kids = append(kids, strconv.Itoa(pagesStart+idx*2)+" 0 R")
+	}
+
+	object("<< /Type /Pages /Kids [" + strings.Join(kids, " ") + "] /Count " + strconv.Itoa(len(d.pages)) + " >>")
+	object("<< /Title (" + string(escape(encode(d.title))) + ") /Producer (glp) /CreationDate (" +
+		d.created.UTC().Format("D:20060102150405Z") + ") >>")
+
+	fontsResources := ""
+
+	for idx, f := range fonts {
+		object("<< /Type /Font /Subtype /Type1 /BaseFont /" + f.base + " /Encoding /WinAnsiEncoding >>")
+		fontsResources += "/" + f.resource + " " + strconv.Itoa(fontsStart+idx) + " 0 R "
+	}
+
+	for idx, p := range d.pages {
+		object("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 " + number(pageWidth) + " " + number(pageHeight) + "] " +
+			"/Resources << /Font << " + fontsResources + ">> >> /Contents " + strconv.Itoa(pagesStart+idx*2+1) + " 0 R >>")
+
+		var compressed bytes.Buffer
+
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return err
+		}
+
+		if err := zw.Close(); err != nil {
+			return err
+		}
+
+		object("<< /Length " + strconv.Itoa(compressed.Len()) + " /Filter /FlateDecode >>\nstream\n" + compressed.String() + "\nendstream")
+	}
+
+	xref := out.count
+
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.err
+}
+
+// This structure counts bytes written into underlying writer, which is
+// required for cross-reference table. First write error is remembered
+// and all following writes are skipped.
+type countingWriter struct {
+	w     io.Writer
+	count int64
+	err   error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+
+	n, err := cw.w.Write(p)
+	cw.count += int64(n)
+	cw.err = err
+
+	return n, err
+}
+
+// Escapes special characters in PDF string literal.
+func escape(s []byte) []byte {
+	result := make([]byte, 0, len(s))
+
+	for _, b := range s {
+		if b == '(' || b == ')' || b == '\\' {
+			result = append(result, '\\')
+		}
+
+		result = append(result, b)
+	}
+
+	return result
+}
+
+// Formats number for PDF operators.
+func number(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+
+	if s == "" || s == "-" {
+		return "0"
+	}
+
+	return s
+}
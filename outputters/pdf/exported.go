@@ -0,0 +1,14 @@
+package pdf
+
+import (
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/outputinterface"
+)
+
+func Initialize() outputinterface.Interface {
+	logger.Debug("Initializing pdf outputter...")
+
+	p := &outputter{}
+	return outputinterface.Interface(p)
+}
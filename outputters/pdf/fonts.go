@@ -0,0 +1,157 @@
+package pdf
+
+import (
+	// stdlib
+	"strings"
+)
+
+// This structure represents one of standard PDF fonts. Standard fonts
+// are available in every PDF viewer, so they aren't embedded.
+type font struct {
+	// Name of font in page resources.
+	resource string
+	// PostScript font name.
+	base string
+	// Glyph widths for WinAnsiEncoding characters in 1/1000 of font
+	// size.
+	widths [256]int
+}
+
+var (
+	fontRegular = newFont("F1", "Helvetica", helveticaWidths)
+	fontBold    = newFont("F2", "Helvetica-Bold", helveticaBoldWidths)
+	fontMono    = newFont("F3", "Courier", nil)
+
+	fonts = []*font{fontRegular, fontBold, fontMono}
+)
+
+// Widths of printable ASCII characters (32-126) from fonts metrics.
+var (
+	helveticaWidths = []int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = []int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// Characters outside of Latin-1 that are present in WinAnsiEncoding.
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// Creates font. Nil ascii widths means monospaced font.
+func newFont(resource string, base string, ascii []int) *font {
+	f := &font{resource: resource, base: base}
+
+	for idx := range f.widths {
+		switch {
+		case ascii == nil:
+			f.widths[idx] = 600
+		case idx >= 32 && idx-32 < len(ascii):
+			f.widths[idx] = ascii[idx-32]
+		case idx == 0xA9 || idx == 0xAE:
+			// Copyright and registered signs.
+			f.widths[idx] = 737
+		default:
+			f.widths[idx] = 556
+		}
+	}
+
+	return f
+}
+
+// Returns width of passed string in points.
+func (f *font) width(s string, size float64) float64 {
+	total := 0
+
+	for _, b := range encode(s) {
+		total += f.widths[b]
+	}
+
+	return float64(total) * size / 1000
+}
+
+// Encodes string into WinAnsiEncoding. Characters that cannot be
+// represented are replaced with question mark.
+func encode(s string) []byte {
+	result := make([]byte, 0, len(s))
+
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			result = append(result, ' ')
+		case r >= 32 && r < 127, r >= 0xA0 && r <= 0xFF:
+			result = append(result, byte(r))
+		case winAnsiExtra[r] != 0:
+			result = append(result, winAnsiExtra[r])
+		default:
+			result = append(result, '?')
+		}
+	}
+
+	return result
+}
+
+// Wraps text to lines that fit into passed width. Explicit line breaks
+// and paragraphs indentation are preserved, words that are longer than width (e.g. long URLs) are
+// broken by characters.
+func (f *font) wrap(s string, size float64, width float64) []string {
+	lines := make([]string, 0)
+
+	for _, paragraph := range strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n") {
+		// Indentation is a part of formatting for license texts.
+		indent := paragraph[:len(paragraph)-len(strings.TrimLeft(paragraph, " \t"))]
+		line := ""
+
+		for idx, word := range strings.Fields(paragraph) {
+			candidate := word
+
+			switch {
+			case idx == 0:
+				candidate = indent + word
+			case line != "":
+				candidate = line + " " + word
+			}
+
+			if f.width(candidate, size) <= width {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+
+			for f.width(word, size) > width {
+				runes := []rune(word)
+				cut := 1
+
+				for cut < len(runes) && f.width(string(runes[:cut+1]), size) <= width {
+					cut++
+				}
+
+				lines = append(lines, string(runes[:cut]))
+				word = string(runes[cut:])
+			}
+
+			line = word
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
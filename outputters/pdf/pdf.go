@@ -0,0 +1,362 @@
+package pdf
+
+import (
+	// stdlib
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/licensetext"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+const (
+	reportTitle = "Third party licenses report"
+
+	// Page margins and footer position.
+	margin  = 50
+	footerY = 30
+
+	// Table cells geometry.
+	cellPadding = 3
+	tableSize   = 8
+)
+
+// This structure represents table column.
+type column struct {
+	title string
+	width float64
+}
+
+// Responsible for writing PDF report.
+type outputter struct{}
+
+func (o *outputter) Write(deps []*structs.Dependency, w io.Writer) error {
+	logger.Info("Got", strconv.Itoa(len(deps)), "dependencies to write")
+
+	sorted := make([]*structs.Dependency, len(deps))
+	copy(sorted, deps)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	r := &report{doc: &document{created: time.Now(), title: reportTitle}}
+
+	r.writeCover(sorted)
+	r.writeSummary(sorted)
+	r.writeDependencies(sorted)
+	r.writeAppendix(sorted)
+	r.writeFooters()
+
+	if err := r.doc.write(w); err != nil {
+		return errors.New("failed to write PDF report: " + err.Error())
+	}
+
+	return nil
+}
+
+// This structure holds report layout state: current page and vertical
+// position on it.
+type report struct {
+	doc  *document
+	page *page
+	y    float64
+}
+
+// Starts new page.
+func (r *report) newPage() {
+	r.page = r.doc.addPage()
+	r.y = pageHeight - margin
+}
+
+// Starts new page if there is no room for passed height on current one.
+// Returns true if new page was started.
+func (r *report) ensure(height float64) bool {
+	if r.page != nil && r.y-height >= margin {
+		return false
+	}
+
+	r.newPage()
+
+	return true
+}
+
+// Writes wrapped text with passed font.
+func (r *report) paragraph(f *font, size float64, s string) {
+	lineHeight := size * 1.3
+
+	for _, line := range f.wrap(s, size, pageWidth-margin*2) {
+		r.ensure(lineHeight)
+		r.y -= lineHeight
+		r.page.text(f, size, margin, r.y+size*0.3, line)
+	}
+}
+
+// Writes section heading. Heading is moved to next page if there is
+// no room for some content after it.
+func (r *report) heading(s string, size float64) {
+	r.ensure(size*2 + 40)
+	r.y -= size * 0.8
+	r.paragraph(fontBold, size, s)
+	r.y -= size * 0.5
+}
+
+// Writes table. Header is repeated on every page table spans.
+func (r *report) table(columns []column, rows [][]string) {
+	lineHeight := tableSize * 1.3
+
+	writeRow := func(f *font, cells []string, background float64) {
+		wrapped := make([][]string, len(columns))
+		lines := 1
+
+		for idx, col := range columns {
+			wrapped[idx] = f.wrap(cells[idx], tableSize, col.width-cellPadding*2)
+			if len(wrapped[idx]) > lines {
+				lines = len(wrapped[idx])
+			}
+		}
+
+		height := float64(lines)*lineHeight + cellPadding*2
+		x := float64(margin)
+
+		if background < 1 {
+			r.page.fillRect(margin, r.y-height, pageWidth-margin*2, height, background)
+		}
+
+		for idx, col := range columns {
+			for lineIdx, line := range wrapped[idx] {
+				r.page.text(f, tableSize, x+cellPadding, r.y-cellPadding-float64(lineIdx+1)*lineHeight+tableSize*0.3, line)
+			}
+
+			x += col.width
+		}
+
+		r.y -= height
+		r.page.line(margin, r.y, pageWidth-margin, r.y, 0.3)
+	}
+
+	header := make([]string, len(columns))
+	for idx, col := range columns {
+		header[idx] = col.title
+	}
+
+	r.ensure(lineHeight*2 + cellPadding*4)
+	writeRow(fontBold, header, 0.85)
+
+	for _, row := range rows {
+		// Row height is estimated by longest cell to decide if it fits.
+		lines := 1
+
+		for idx, col := range columns {
+			if l := len(fontRegular.wrap(row[idx], tableSize, col.width-cellPadding*2)); l > lines {
+				lines = l
+			}
+		}
+
+		if r.ensure(float64(lines)*lineHeight + cellPadding*2) {
+			writeRow(fontBold, header, 0.85)
+		}
+
+		writeRow(fontRegular, row, 1)
+	}
+
+	r.y -= 10
+}
+
+// Writes cover page with generation date and projects list.
+func (r *report) writeCover(deps []*structs.Dependency) {
+	r.newPage()
+	r.y = pageHeight - 200
+
+	r.paragraph(fontBold, 26, reportTitle)
+	r.y -= 20
+	r.paragraph(fontRegular, 11, "Generated: "+r.doc.created.Format("2006-01-02 15:04:05 MST"))
+	r.paragraph(fontRegular, 11, "Dependencies: "+strconv.Itoa(len(deps)))
+	r.paragraph(fontRegular, 11, "Licenses: "+strconv.Itoa(len(countLicenses(deps))))
+	r.y -= 20
+
+	r.paragraph(fontBold, 13, "Projects")
+	r.y -= 4
+
+	for _, project := range projects(deps) {
+		r.paragraph(fontRegular, 11, "•  "+project)
+	}
+}
+
+// Writes licenses summary table.
+func (r *report) writeSummary(deps []*structs.Dependency) {
+	r.newPage()
+	r.heading("License summary", 16)
+
+	counts := countLicenses(deps)
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] == counts[names[j]] {
+			return names[i] < names[j]
+		}
+
+		return counts[names[i]] > counts[names[j]]
+	})
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		percent := strconv.FormatFloat(float64(counts[name])*100/float64(len(deps)), 'f', 1, 64) + "%"
+		rows = append(rows, []string{name, strconv.Itoa(counts[name]), percent})
+	}
+
+	r.table([]column{{"License", 295}, {"Dependencies", 100}, {"Share", 100}}, rows)
+}
+
+// Writes dependencies table.
+func (r *report) writeDependencies(deps []*structs.Dependency) {
+	r.heading("Dependencies", 16)
+
+	rows := make([][]string, 0, len(deps))
+	for _, dep := range deps {
+		rows = append(rows, []string{dep.Name, dep.Version, licenseName(dep), strings.Join(parents(dep), "\n")})
+	}
+
+	r.table([]column{{"Module", 190}, {"Version", 90}, {"License", 85}, {"Project", 130}}, rows)
+}
+
+// Writes appendix with full license texts. Identical texts are written
+// once with list of dependencies using them.
+func (r *report) writeAppendix(deps []*structs.Dependency) {
+	r.newPage()
+	r.heading("Appendix: license texts", 16)
+
+	byLicense := make(map[string][]*structs.Dependency)
+	for _, dep := range deps {
+		byLicense[licensetext.Name(dep)] = append(byLicense[licensetext.Name(dep)], dep)
+	}
+
+	names := make([]string, 0, len(byLicense))
+	for name := range byLicense {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		r.heading(name, 13)
+
+		texts := licensetext.Collect(byLicense[name])
+		if len(texts) == 0 {
+			r.paragraph(fontRegular, 9, "License text isn't available.")
+			r.y -= 10
+
+			continue
+		}
+
+		for _, text := range texts {
+			r.paragraph(fontBold, 9, "Used by:")
+			r.paragraph(fontRegular, 9, strings.Join(text.Users, ", "))
+
+			if len(text.Copyrights) > 0 {
+				r.y -= 4
+				r.paragraph(fontBold, 9, "Copyrights:")
+				r.paragraph(fontRegular, 9, strings.Join(text.Copyrights, "\n"))
+			}
+
+			r.y -= 6
+			r.paragraph(fontMono, 7, text.Text)
+			r.y -= 14
+		}
+	}
+
+	// NOTICE files should be redistributed as is.
+	notices := make([]*structs.Dependency, 0)
+
+	for _, dep := range deps {
+		if dep.License.Notice != "" {
+			notices = append(notices, dep)
+		}
+	}
+
+	if len(notices) == 0 {
+		return
+	}
+
+	r.newPage()
+	r.heading("Appendix: NOTICE files", 16)
+
+	for _, dep := range notices {
+		r.heading(dep.Name+" "+dep.Version, 11)
+		r.paragraph(fontMono, 7, dep.License.Notice)
+		r.y -= 14
+	}
+}
+
+// Writes page numbers on every page.
+func (r *report) writeFooters() {
+	total := strconv.Itoa(len(r.doc.pages))
+
+	for idx, p := range r.doc.pages {
+		label := "Page " + strconv.Itoa(idx+1) + " of " + total
+
+		p.line(margin, footerY+12, pageWidth-margin, footerY+12, 0.3)
+		p.text(fontRegular, 8, margin, footerY, reportTitle)
+		p.text(fontRegular, 8, pageWidth-margin-fontRegular.width(label, 8), footerY, label)
+	}
+}
+
+// Returns dependencies count per license.
+func countLicenses(deps []*structs.Dependency) map[string]int {
+	counts := make(map[string]int)
+
+	for _, dep := range deps {
+		counts[licenseName(dep)]++
+	}
+
+	return counts
+}
+
+// Returns license name for dependency.
+func licenseName(dep *structs.Dependency) string {
+	if dep.License.Name == "" {
+		return "Unknown"
+	}
+
+	return dep.License.Name
+}
+
+// Returns list of dependency's parents.
+func parents(dep *structs.Dependency) []string {
+	if len(dep.Parents) > 0 {
+		return dep.Parents
+	}
+
+	if dep.Parent == "" {
+		return []string{}
+	}
+
+	return strings.Split(dep.Parent, ",")
+}
+
+// Returns sorted list of all projects.
+func projects(deps []*structs.Dependency) []string {
+	found := make(map[string]bool)
+
+	for _, dep := range deps {
+		for _, parent := range parents(dep) {
+			found[parent] = true
+		}
+	}
+
+	result := make([]string, 0, len(found))
+	for project := range found {
+		result = append(result, project)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,14 @@
+package template
+
+import (
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/outputinterface"
+)
+
+func Initialize() outputinterface.Interface {
+	logger.Debug("Initializing template outputter...")
+
+	t := &outputter{}
+	return outputinterface.Interface(t)
+}
@@ -0,0 +1,119 @@
+package template
+
+import (
+	// stdlib
+	"encoding/json"
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// LicenseGroup is a list of dependencies licensed under same license.
+// It is returned by "groupByLicense" template function.
+type LicenseGroup struct {
+	// License is a license name.
+	License string
+	// Dependencies is a list of dependencies sorted by name.
+	Dependencies []*structs.Dependency
+}
+
+// Returns functions available in templates.
+func templateFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"groupByLicense": groupByLicense,
+		"sortBy":         sortBy,
+		"join":           strings.Join,
+		"lower":          strings.ToLower,
+		"upper":          strings.ToUpper,
+		"replace":        func(s string, old string, new string) string { return strings.Replace(s, old, new, -1) },
+		"escapeCSV":      escapeCSV,
+		"escapeMarkdown": escapeMarkdown,
+		"escapeJSON":     escapeJSON,
+	}
+}
+
+// Groups dependencies by license. Groups are sorted by license name.
+func groupByLicense(deps []*structs.Dependency) []*LicenseGroup {
+	groupsMap := make(map[string]*LicenseGroup)
+
+	for _, dep := range deps {
+		group, found := groupsMap[dep.License.Name]
+		if !found {
+			group = &LicenseGroup{License: dep.License.Name}
+			groupsMap[dep.License.Name] = group
+		}
+
+		group.Dependencies = append(group.Dependencies, dep)
+	}
+
+	groups := make([]*LicenseGroup, 0, len(groupsMap))
+
+	for _, group := range groupsMap {
+		group.Dependencies = sortBy("Name", group.Dependencies)
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].License < groups[j].License })
+
+	return groups
+}
+
+// Returns copy of dependencies list sorted by passed field. Supported
+// fields are "Name", "Version", "License" and "Parent".
+func sortBy(field string, deps []*structs.Dependency) []*structs.Dependency {
+	value := func(dep *structs.Dependency) string {
+		switch strings.ToLower(field) {
+		case "version":
+			return dep.Version
+		case "license":
+			return dep.License.Name
+		case "parent", "project":
+			return dep.Parent
+		}
+
+		return dep.Name
+	}
+
+	sorted := make([]*structs.Dependency, len(deps))
+	copy(sorted, deps)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		vi, vj := value(sorted[i]), value(sorted[j])
+		if vi == vj {
+			return sorted[i].Name < sorted[j].Name
+		}
+
+		return vi < vj
+	})
+
+	return sorted
+}
+
+// Escapes value to be used as CSV field. Field is quoted only if it
+// contains quotes, delimiter or line breaks.
+func escapeCSV(delimiter string, value string) string {
+	if !strings.ContainsAny(value, "\"\r\n"+delimiter) {
+		return value
+	}
+
+	return "\"" + strings.Replace(value, "\"", "\"\"", -1) + "\""
+}
+
+// Escapes Markdown special characters.
+func escapeMarkdown(value string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]",
+		"<", "\\<", ">", "\\>", "|", "\\|", "#", "\\#",
+	)
+
+	return replacer.Replace(value)
+}
+
+// Escapes value to be used inside JSON string literal (without quotes).
+func escapeJSON(value string) string {
+	data, _ := json.Marshal(value)
+
+	return string(data[1 : len(data)-1])
+}
@@ -0,0 +1,135 @@
+package template
+
+import (
+	// stdlib
+	"errors"
+	ht "html/template"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	tt "text/template"
+	"time"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+const (
+	// Template engines.
+	engineText = "text"
+	engineHTML = "html"
+)
+
+// Data is a data passed to template.
+type Data struct {
+	// Dependencies is a list of dependencies.
+	Dependencies []*structs.Dependency
+	// Generated is a report generation timestamp.
+	Generated time.Time
+	// Projects is a sorted list of projects dependencies belongs to.
+	Projects []string
+}
+
+// This interface is implemented by both text and HTML templates.
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// Responsible for rendering dependencies with user-supplied template.
+type outputter struct{}
+
+func (o *outputter) Write(deps []*structs.Dependency, w io.Writer) error {
+	logger.Info("Got", strconv.Itoa(len(deps)), "dependencies to write")
+
+	tpl, err := loadTemplate()
+	if err != nil {
+		return errors.New("failed to load template: " + err.Error())
+	}
+
+	data := &Data{
+		Dependencies: deps,
+		Generated:    time.Now(),
+		Projects:     projects(deps),
+	}
+
+	if err := tpl.Execute(w, data); err != nil {
+		return errors.New("failed to render template: " + err.Error())
+	}
+
+	return nil
+}
+
+// Check loads and parses template configured in configuration file and
+// returns error if it cannot be used. It allows to fail before
+// dependencies are scanned.
+func Check() error {
+	_, err := loadTemplate()
+
+	return err
+}
+
+// Loads template configured in configuration file. Template engine is
+// taken from configuration or determined by template file extension.
+func loadTemplate() (executor, error) {
+	cfg := configuration.Cfg.Outputs.Template
+
+	if cfg.Path == "" {
+		return nil, errors.New("template path isn't configured")
+	}
+
+	data, err := ioutil.ReadFile(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := cfg.Engine
+	if engine == "" {
+		engine = engineText
+
+		ext := strings.ToLower(filepath.Ext(cfg.Path))
+		if ext == ".html" || ext == ".htm" {
+			engine = engineHTML
+		}
+	}
+
+	name := filepath.Base(cfg.Path)
+
+	switch engine {
+	case engineText:
+		return tt.New(name).Funcs(templateFuncs()).Parse(string(data))
+	case engineHTML:
+		return ht.New(name).Funcs(templateFuncs()).Parse(string(data))
+	}
+
+	return nil, errors.New("unknown template engine '" + engine + "', should be 'text' or 'html'")
+}
+
+// Returns sorted list of unique projects from dependencies list.
+func projects(deps []*structs.Dependency) []string {
+	seen := make(map[string]bool)
+
+	var result []string
+
+	for _, dep := range deps {
+		parents := dep.Parents
+		if len(parents) == 0 {
+			parents = []string{dep.Parent}
+		}
+
+		for _, parent := range parents {
+			if parent != "" && !seen[parent] {
+				seen[parent] = true
+				result = append(result, parent)
+			}
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
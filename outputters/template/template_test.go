@@ -0,0 +1,56 @@
+package template
+
+import (
+	// stdlib
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+)
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	configuration.InitializeForTest(t, "")
+
+	tests := []struct {
+		name     string
+		file     string
+		template string
+		engine   string
+		valid    bool
+	}{
+		{name: "text template", file: "report.txt", template: "{{ range .Dependencies }}{{ .Name | upper }}{{ end }}", valid: true},
+		{name: "html template", file: "report.html", template: "<p>{{ len .Projects }}</p>", valid: true},
+		{name: "syntax error", file: "report.txt", template: "{{ range .Dependencies }}{{ .Name }"},
+		{name: "unknown function", file: "report.txt", template: "{{ unknown .Dependencies }}"},
+		{name: "unknown engine", file: "report.txt", template: "{{ .Generated }}", engine: "markdown"},
+		{name: "missing file", file: "missing.txt"},
+		{name: "path isn't configured"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := ""
+
+			if test.file != "" {
+				path = filepath.Join(dir, test.file)
+			}
+
+			if test.template != "" {
+				if err := ioutil.WriteFile(path, []byte(test.template), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			configuration.Cfg.Outputs.Template.Path = path
+			configuration.Cfg.Outputs.Template.Engine = test.engine
+
+			if err := Check(); (err == nil) != test.valid {
+				t.Errorf("Check() = %v, want valid %v", err, test.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package xlsx
+
+import (
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/outputters/outputinterface"
+)
+
+func Initialize() outputinterface.Interface {
+	logger.Debug("Initializing xlsx outputter...")
+
+	x := &outputter{}
+	return outputinterface.Interface(x)
+}
@@ -0,0 +1,343 @@
+package xlsx
+
+import (
+	// stdlib
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Cell styles indexes, see stylesXML.
+const (
+	styleDefault = 0
+	styleHeader  = 1
+	styleWrap    = 2
+)
+
+// Characters that aren't allowed in sheet names.
+var sheetNameReplacer = strings.NewReplacer("[", "_", "]", "_", ":", "_", "*", "_", "?", "_", "/", "_", "\\", "_")
+
+// This structure represents single worksheet.
+type sheet struct {
+	name       string
+	widths     []float64
+	rows       [][]string
+	autoFilter bool
+	// Header row is frozen and styled if true.
+	header bool
+	// Columns which cells should be wrapped (e.g. multi-line values).
+	wrapColumns map[int]bool
+	// Columns which cells are written as numbers, so they can be used
+	// in formulas. Other cells are always written as strings.
+	numericColumns map[int]bool
+}
+
+// This structure represents workbook with worksheets.
+type workbook struct {
+	sheets []*sheet
+	names  map[string]bool
+}
+
+// Creates new workbook.
+func newWorkbook() *workbook {
+	return &workbook{names: make(map[string]bool)}
+}
+
+// Adds sheet into workbook. Sheet name is sanitized and made unique as
+// Excel requires.
+func (wb *workbook) addSheet(name string) *sheet {
+	name = sheetNameReplacer.Replace(name)
+	if name == "" {
+		name = "Sheet"
+	}
+
+	// Sheet names are limited to 31 characters.
+	base := name
+	if len([]rune(base)) > 31 {
+		base = string([]rune(base)[:31])
+	}
+
+	name = base
+
+	for idx := 2; wb.names[strings.ToLower(name)]; idx++ {
+		suffix := " (" + strconv.Itoa(idx) + ")"
+		runes := []rune(base)
+
+		if len(runes)+len(suffix) > 31 {
+			runes = runes[:31-len(suffix)]
+		}
+
+		name = string(runes) + suffix
+	}
+
+	wb.names[strings.ToLower(name)] = true
+
+	s := &sheet{name: name, wrapColumns: make(map[int]bool), numericColumns: make(map[int]bool)}
+	wb.sheets = append(wb.sheets, s)
+
+	return s
+}
+
+// Writes workbook as XLSX file into passed writer.
+func (wb *workbook) write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data string
+	}{
+		{"[Content_Types].xml", wb.contentTypesXML()},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", wb.workbookXML()},
+		{"xl/_rels/workbook.xml.rels", wb.workbookRelsXML()},
+		{"xl/styles.xml", stylesXML},
+	}
+
+	for idx, s := range wb.sheets {
+		files = append(files, struct {
+			name string
+			data string
+		}{"xl/worksheets/sheet" + strconv.Itoa(idx+1) + ".xml", s.xml()})
+	}
+
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(fw, file.data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// Returns [Content_Types].xml data.
+func (wb *workbook) contentTypesXML() string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	for idx := range wb.sheets {
+		b.WriteString(`<Override PartName="/xl/worksheets/sheet` + strconv.Itoa(idx+1) + `.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`)
+	}
+
+	b.WriteString(`</Types>`)
+
+	return b.String()
+}
+
+// Returns xl/workbook.xml data.
+func (wb *workbook) workbookXML() string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+
+	for idx, s := range wb.sheets {
+		id := strconv.Itoa(idx + 1)
+		b.WriteString(`<sheet name="` + escape(s.name) + `" sheetId="` + id + `" r:id="rId` + id + `"/>`)
+	}
+
+	b.WriteString(`</sheets>`)
+
+	// Excel expects hidden defined name for every auto filter.
+	var definedNames strings.Builder
+
+	for idx, s := range wb.sheets {
+		if !s.autoFilter || len(s.rows) == 0 {
+			continue
+		}
+
+		definedNames.WriteString(`<definedName name="_xlnm._FilterDatabase" localSheetId="` + strconv.Itoa(idx) + `" hidden="1">'` +
+			escape(strings.Replace(s.name, "'", "''", -1)) + `'!` + s.absoluteRange() + `</definedName>`)
+	}
+
+	if definedNames.Len() > 0 {
+		b.WriteString(`<definedNames>` + definedNames.String() + `</definedNames>`)
+	}
+
+	b.WriteString(`</workbook>`)
+
+	return b.String()
+}
+
+// Returns xl/_rels/workbook.xml.rels data.
+func (wb *workbook) workbookRelsXML() string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for idx := range wb.sheets {
+		id := strconv.Itoa(idx + 1)
+		b.WriteString(`<Relationship Id="rId` + id + `" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet` + id + `.xml"/>`)
+	}
+
+	stylesID := strconv.Itoa(len(wb.sheets) + 1)
+	b.WriteString(`<Relationship Id="rId` + stylesID + `" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+	b.WriteString(`</Relationships>`)
+
+	return b.String()
+}
+
+// Returns worksheet XML data.
+func (s *sheet) xml() string {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+
+	if s.header {
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+			`<selection pane="bottomLeft" activeCell="A2" sqref="A2"/></sheetView></sheetViews>`)
+	}
+
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+
+		for idx, width := range s.widths {
+			col := strconv.Itoa(idx + 1)
+			b.WriteString(`<col min="` + col + `" max="` + col + `" width="` + strconv.FormatFloat(width, 'f', 1, 64) + `" customWidth="1"/>`)
+		}
+
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+
+	for rowIdx, row := range s.rows {
+		b.WriteString(`<row r="` + strconv.Itoa(rowIdx+1) + `">`)
+
+		for colIdx, value := range row {
+			style := styleDefault
+
+			switch {
+			case s.header && rowIdx == 0:
+				style = styleHeader
+			case s.wrapColumns[colIdx]:
+				style = styleWrap
+			}
+
+			b.WriteString(`<c r="` + cellName(colIdx, rowIdx) + `"`)
+
+			if style != styleDefault {
+				b.WriteString(` s="` + strconv.Itoa(style) + `"`)
+			}
+
+			if s.isNumeric(colIdx, rowIdx, value) {
+				b.WriteString(`><v>` + value + `</v></c>`)
+				continue
+			}
+
+			b.WriteString(` t="inlineStr"><is><t xml:space="preserve">` + escape(value) + `</t></is></c>`)
+		}
+
+		b.WriteString(`</row>`)
+	}
+
+	b.WriteString(`</sheetData>`)
+
+	if s.autoFilter && len(s.rows) > 0 {
+		b.WriteString(`<autoFilter ref="` + s.dataRange() + `"/>`)
+	}
+
+	b.WriteString(`</worksheet>`)
+
+	return b.String()
+}
+
+// Returns true if cell should be written as number. Value is checked
+// too, so numeric column might contain text (e.g. in total row).
+func (s *sheet) isNumeric(column int, row int, value string) bool {
+	if !s.numericColumns[column] || s.header && row == 0 {
+		return false
+	}
+
+	_, err := strconv.Atoi(value)
+
+	return err == nil && len(value) < 15
+}
+
+// Returns range covering all sheet data, e.g. "A1:G10".
+func (s *sheet) dataRange() string {
+	return "A1:" + cellName(s.columnsCount()-1, len(s.rows)-1)
+}
+
+// Returns range covering all sheet data with absolute references,
+// e.g. "$A$1:$G$10".
+func (s *sheet) absoluteRange() string {
+	last := cellName(s.columnsCount()-1, len(s.rows)-1)
+	column := strings.TrimRight(last, "0123456789")
+
+	return "$A$1:$" + column + "$" + strings.TrimPrefix(last, column)
+}
+
+// Returns maximum columns count in sheet rows.
+func (s *sheet) columnsCount() int {
+	count := 1
+
+	for _, row := range s.rows {
+		if len(row) > count {
+			count = len(row)
+		}
+	}
+
+	return count
+}
+
+// Returns cell name (e.g. "B3") for zero-based column and row indexes.
+func cellName(column int, row int) string {
+	name := ""
+
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+
+	return name + strconv.Itoa(row+1)
+}
+
+// Escapes text for XML and removes characters that aren't allowed in
+// XML documents.
+func escape(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || r >= 0x20 && r != 0xFFFE && r != 0xFFFF {
+			return r
+		}
+
+		return -1
+	}, s)
+
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
+
+const rootRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// Styles: 0 - default, 1 - bold header, 2 - wrapped text aligned to top.
+const stylesXML = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0" applyAlignment="1"><alignment vertical="top" wrapText="1"/></xf>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
@@ -0,0 +1,60 @@
+package xlsx
+
+import (
+	// stdlib
+	"strings"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/structs"
+)
+
+func TestSheetXML(t *testing.T) {
+	deps := []*structs.Dependency{
+		{Name: "example.com/007", Version: "007", License: structs.License{Name: "+5"}, Parent: "1"},
+		{Name: "example.com/dep", Version: "2", License: structs.License{Name: "MIT"}, Parent: "-3"},
+	}
+
+	wb := newWorkbook()
+	fillDependenciesSheet(wb.addSheet("Dependencies"), deps)
+	fillSummarySheet(wb.addSheet("Summary"), deps)
+
+	tests := []struct {
+		name    string
+		xml     string
+		numbers []string
+		strings []string
+	}{
+		{
+			name:    "dependencies fields are strings",
+			xml:     wb.sheets[0].xml(),
+			strings: []string{"007", "+5", "1", "2", "-3"},
+		},
+		{
+			name:    "summary counts are numbers",
+			xml:     wb.sheets[1].xml(),
+			numbers: []string{`<c r="B2"><v>1</v></c>`, `<c r="B3"><v>1</v></c>`, `<c r="B4"><v>2</v></c>`},
+			strings: []string{"+5", "Dependencies", "Total"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, number := range test.numbers {
+				if !strings.Contains(test.xml, number) {
+					t.Errorf("sheet doesn't contain %q", number)
+				}
+			}
+
+			if count := strings.Count(test.xml, "<v>"); count != len(test.numbers) {
+				t.Errorf("sheet contains %d numeric cells, want %d", count, len(test.numbers))
+			}
+
+			for _, value := range test.strings {
+				if !strings.Contains(test.xml, `<t xml:space="preserve">`+value+`</t>`) {
+					t.Errorf("sheet doesn't contain string %q", value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package xlsx
+
+import (
+	// stdlib
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+var (
+	// Same columns as in CSV report.
+	headers = []string{"Module", "Version", "License", "Repository URL", "License URL", "Project", "Copyrights"}
+	widths  = []float64{45, 20, 15, 45, 60, 35, 60}
+)
+
+// Responsible for writing XLSX workbook.
+type outputter struct{}
+
+func (o *outputter) Write(deps []*structs.Dependency, w io.Writer) error {
+	logger.Info("Got", strconv.Itoa(len(deps)), "dependencies to write")
+
+	wb := newWorkbook()
+
+	fillDependenciesSheet(wb.addSheet("Dependencies"), deps)
+	fillSummarySheet(wb.addSheet("Summary"), deps)
+
+	// Every project gets it's own sheet.
+	byProject := make(map[string][]*structs.Dependency)
+
+	for _, dep := range deps {
+		for _, parent := range parents(dep) {
+			byProject[parent] = append(byProject[parent], dep)
+		}
+	}
+
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+
+	sort.Strings(projects)
+
+	for _, project := range projects {
+		fillDependenciesSheet(wb.addSheet(projectSheetName(project)), byProject[project])
+	}
+
+	if err := wb.write(w); err != nil {
+		return errors.New("failed to write XLSX report: " + err.Error())
+	}
+
+	return nil
+}
+
+// Fills sheet with dependencies table.
+func fillDependenciesSheet(s *sheet, deps []*structs.Dependency) {
+	s.header = true
+	s.autoFilter = true
+	s.widths = widths
+	s.wrapColumns[len(headers)-1] = true
+	s.rows = append(s.rows, headers)
+
+	for _, dep := range deps {
+		s.rows = append(s.rows, []string{
+			dep.Name, dep.Version, dep.License.Name, dep.VCS.VCSPath, dep.License.URL, dep.Parent,
+			// Every copyright gets it's own line within cell.
+			strings.Join(dep.License.Copyrights, "\n"),
+		})
+	}
+}
+
+// Fills sheet with dependencies count per license.
+func fillSummarySheet(s *sheet, deps []*structs.Dependency) {
+	s.header = true
+	s.widths = []float64{30, 15}
+	s.numericColumns[1] = true
+	s.rows = append(s.rows, []string{"License", "Dependencies"})
+
+	counts := make(map[string]int)
+
+	for _, dep := range deps {
+		name := dep.License.Name
+		if name == "" {
+			name = "Unknown"
+		}
+
+		counts[name]++
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] == counts[names[j]] {
+			return names[i] < names[j]
+		}
+
+		return counts[names[i]] > counts[names[j]]
+	})
+
+	for _, name := range names {
+		s.rows = append(s.rows, []string{name, strconv.Itoa(counts[name])})
+	}
+
+	s.rows = append(s.rows, []string{"Total", strconv.Itoa(len(deps))})
+}
+
+// Returns list of dependency's parents.
+func parents(dep *structs.Dependency) []string {
+	if len(dep.Parents) > 0 {
+		return dep.Parents
+	}
+
+	if dep.Parent == "" {
+		return []string{"Unknown project"}
+	}
+
+	return []string{dep.Parent}
+}
+
+// Returns sheet name for project. Project names are usually long paths,
+// so only last path elements are used.
+func projectSheetName(project string) string {
+	name := project
+	for len(name) > 31 && strings.Contains(name, "/") {
+		name = name[strings.Index(name, "/")+1:]
+	}
+
+	return name
+}
@@ -3,30 +3,75 @@ package parsers
 import (
 	// stdlib
 	"errors"
-	"log"
 	"sync"
 
 	// local
-	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/parsers/external"
 	"go.dev.pztrn.name/glp/parsers/golang"
 	"go.dev.pztrn.name/glp/parsers/parserinterface"
+	"go.dev.pztrn.name/glp/plugins"
 	"go.dev.pztrn.name/glp/structs"
 )
 
 var (
-	parsers      map[string]parserinterface.Interface
+	parsers      = make(map[string]parserinterface.Interface)
 	parsersMutex sync.RWMutex
+	// Parsers names in registration order. Parsers are asked to detect
+	// project in this order.
+	parsersOrder []string
 )
 
-// Initialize initializes package.
+// Initialize initializes package. Built-in parsers are registered first,
+// then external parsers found in PATH (executables named
+// "glp-parser-<name>").
 func Initialize() {
-	log.Println("Initializing parsers...")
-
-	parsers = make(map[string]parserinterface.Interface)
+	logger.Debug("Initializing parsers...")
 
 	// Initialize parsers.
 	golangIface, golangName := golang.Initialize()
-	parsers[golangName] = golangIface
+	if isRegistered(golangName) {
+		logger.Debug("Built-in parser '" + golangName + "' is overridden by registered one")
+	} else if err := Register(golangName, golangIface); err != nil {
+		logger.Warn("Failed to register parser:", err.Error())
+	}
+
+	for _, executable := range plugins.Discover(plugins.ParserPrefix) {
+		externalIface, externalName := external.Initialize(executable)
+		if err := Register(externalName, externalIface); err != nil {
+			logger.Warn("Failed to register external parser '"+executable.Path+"':", err.Error())
+		}
+	}
+}
+
+// Register adds parser with passed name. Parsers registered earlier
+// have precedence when detecting project type, so parsers registered
+// before Initialize() call are asked before built-in ones.
+func Register(name string, parser parserinterface.Interface) error {
+	if name == "" || name == "unknown" {
+		return errors.New("invalid parser name '" + name + "'")
+	}
+
+	parsersMutex.Lock()
+	defer parsersMutex.Unlock()
+
+	if _, found := parsers[name]; found {
+		return errors.New("parser '" + name + "' is already registered")
+	}
+
+	parsers[name] = parser
+	parsersOrder = append(parsersOrder, name)
+
+	return nil
+}
+
+// Returns true if parser with passed name is registered.
+func isRegistered(name string) bool {
+	parsersMutex.RLock()
+	_, found := parsers[name]
+	parsersMutex.RUnlock()
+
+	return found
 }
 
 // Detect tries to launch parsers for project detection. It returns
@@ -36,10 +81,10 @@ func Detect(pkgPath string) (string, string) {
 	parsersMutex.RLock()
 	defer parsersMutex.RUnlock()
 
-	for parserName, parserIface := range parsers {
-		if configuration.Cfg.Log.Debug {
-			log.Println("Checking if parser '" + parserName + "' can parse project '" + pkgPath + "'...")
-		}
+	for _, parserName := range parsersOrder {
+		parserIface := parsers[parserName]
+
+		logger.Debug("Checking if parser '" + parserName + "' can parse project '" + pkgPath + "'...")
 
 		useThisParser, flavor := parserIface.Detect(pkgPath)
 		if useThisParser {
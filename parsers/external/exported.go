@@ -0,0 +1,16 @@
+package external
+
+import (
+	// local
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/parsers/parserinterface"
+	"go.dev.pztrn.name/glp/plugins"
+)
+
+// Initialize creates parser that delegates work to external executable.
+func Initialize(executable *plugins.Executable) (parserinterface.Interface, string) {
+	logger.Debug("Initializing external parser '" + executable.Name + "' (" + executable.Path + ")")
+
+	p := &externalParser{executable: executable}
+	return parserinterface.Interface(p), executable.Name
+}
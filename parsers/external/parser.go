@@ -0,0 +1,86 @@
+package external
+
+import (
+	// stdlib
+	"strconv"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/plugins"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+const (
+	// Commands sent to external parser.
+	commandDetect       = "detect"
+	commandDependencies = "dependencies"
+)
+
+// This structure is a request sent to external parser on stdin.
+type request struct {
+	Command string `json:"command"`
+	Flavor  string `json:"flavor,omitempty"`
+	Path    string `json:"path"`
+}
+
+// This structure is a response for "detect" command.
+type detectResponse struct {
+	Detected bool   `json:"detected"`
+	Flavor   string `json:"flavor"`
+}
+
+// This structure is a response for "dependencies" command.
+type dependenciesResponse struct {
+	Dependencies []*structs.Dependency `json:"dependencies"`
+}
+
+// Parser that executes external executable for detecting projects and
+// obtaining their dependencies.
+type externalParser struct {
+	executable *plugins.Executable
+}
+
+// Detect asks external executable if it can parse project.
+func (ep *externalParser) Detect(pkgPath string) (bool, string) {
+	response := &detectResponse{}
+
+	err := ep.executable.Call(&request{Command: commandDetect, Path: pkgPath}, response)
+	if err != nil {
+		logger.Warn("External parser '"+ep.executable.Name+"' failed to detect project:", err.Error())
+		return false, ""
+	}
+
+	return response.Detected, response.Flavor
+}
+
+// GetDependencies asks external executable for project's dependencies.
+func (ep *externalParser) GetDependencies(flavor string, pkgPath string) []*structs.Dependency {
+	response := &dependenciesResponse{}
+
+	err := ep.executable.Call(&request{Command: commandDependencies, Flavor: flavor, Path: pkgPath}, response)
+	if err != nil {
+		logger.Error("External parser '"+ep.executable.Name+"' failed to get dependencies:", err.Error())
+		diagnostics.Error(diagnostics.CodeDependenciesReadFailed, pkgPath, pkgPath, err.Error())
+
+		return nil
+	}
+
+	deps := make([]*structs.Dependency, 0, len(response.Dependencies))
+
+	for _, dep := range response.Dependencies {
+		if dep == nil || dep.Name == "" {
+			continue
+		}
+
+		if dep.Parent == "" {
+			dep.Parent = pkgPath
+		}
+
+		deps = append(deps, dep)
+	}
+
+	logger.Debug("External parser '" + ep.executable.Name + "' returned " + strconv.Itoa(len(deps)) + " dependencies")
+
+	return deps
+}
@@ -0,0 +1,146 @@
+package golang
+
+import (
+	// stdlib
+	"debug/buildinfo"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/structs"
+	"go.dev.pztrn.name/glp/workers"
+)
+
+// Detects if passed path is a Go executable with embedded build
+// information.
+func (gp *golangParser) detectBinary(pkgPath string) bool {
+	info, err := os.Stat(pkgPath)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	buildInfo, err1 := buildinfo.ReadFile(pkgPath)
+	if err1 != nil {
+		logger.Debug("File '"+pkgPath+"' isn't a Go executable:", err1.Error())
+		return false
+	}
+
+	logger.Info("Project '" + pkgPath + "' is a Go executable built with " + buildInfo.GoVersion)
+
+	return true
+}
+
+// Gets dependencies from build information embedded into Go executable.
+// Modules are taken from modules cache or downloaded from modules proxy
+// and verified against checksums from build information.
+func (gp *golangParser) getDependenciesFromBinary(pkgPath string) []*structs.Dependency {
+	info, err := buildinfo.ReadFile(pkgPath)
+	if err != nil {
+		logger.Error("Failed to read build information from executable:", err.Error())
+		diagnostics.Error(diagnostics.CodeDependenciesReadFailed, pkgPath, pkgPath, err.Error())
+
+		return nil
+	}
+
+	// Main package path is a parent, executable path is used if
+	// executable was built without modules.
+	parent := info.Path
+	if parent == "" {
+		parent = pkgPath
+	}
+
+	deps := make([]*structs.Dependency, 0, len(info.Deps))
+	modules := make([]*moduleChecksum, 0, len(info.Deps))
+
+	var wg sync.WaitGroup
+
+	for _, module := range info.Deps {
+		// Replacement module's code is compiled into executable, so it
+		// is reported. Replacement with local directory has no version
+		// and original module is reported.
+		source := module
+		if module.Replace != nil && module.Replace.Version != "" {
+			source = module.Replace
+		}
+
+		dependency := newModuleDependency(source.Path, source.Version, "", parent)
+		deps = append(deps, dependency)
+
+		// Local directory content has no checksum.
+		if module.Replace != nil && module.Replace.Version == "" {
+			dependency.LocalPath = localReplacementPath(dependency, module.Replace.Path)
+			continue
+		}
+
+		dependency.Checksum = source.Sum
+		modules = append(modules, &moduleChecksum{dependency: dependency, path: source.Path, version: source.Version})
+
+		wg.Add(1)
+
+		go func(dependency *structs.Dependency, source *debug.Module) {
+			dependency.LocalPath = resolveModule(dependency, source.Path, source.Version)
+			wg.Done()
+		}(dependency, source)
+	}
+
+	wg.Wait()
+
+	// Modules in cache might be modified and downloaded modules might
+	// differ from ones executable was built with.
+	verifyModules(modules)
+
+	return deps
+}
+
+// Returns path to directory module was replaced with. Relative paths
+// are relative to main module's directory which is unknown for
+// executable.
+func localReplacementPath(dependency *structs.Dependency, replacePath string) string {
+	if info, err := os.Stat(replacePath); err == nil && info.IsDir() && filepath.IsAbs(replacePath) {
+		return replacePath
+	}
+
+	logger.Warn("Module", dependency.Name, "was replaced with directory", replacePath, "which isn't available")
+	diagnostics.DependencyError(dependency, diagnostics.CodeModuleDownloadFailed, "module was replaced with directory "+replacePath+" which isn't available")
+
+	return ""
+}
+
+// Returns path to module's directory in modules cache, downloading
+// module from modules proxy if it isn't there.
+func resolveModule(dependency *structs.Dependency, modulePath string, version string) string {
+	dirName, err := moduleDirName(modulePath, version)
+	if err != nil {
+		diagnostics.DependencyError(dependency, diagnostics.CodeModuleDownloadFailed, err.Error())
+		return ""
+	}
+
+	if cacheDir := moduleCacheDir(); cacheDir != "" {
+		if _, err1 := os.Stat(filepath.Join(cacheDir, dirName)); err1 == nil {
+			return filepath.Join(cacheDir, dirName)
+		}
+	}
+
+	// Same module might be used by several executables.
+	result := workers.Do("module:"+modulePath+"@"+version, func() interface{} {
+		moduleDir, err := downloadModule(modulePath, version)
+		if err != nil {
+			return err
+		}
+
+		return moduleDir
+	})
+
+	if err, ok := result.(error); ok {
+		logger.Warn("Failed to get module", modulePath+"@"+version+":", err.Error())
+		diagnostics.DependencyError(dependency, diagnostics.CodeModuleDownloadFailed, err.Error())
+
+		return ""
+	}
+
+	return result.(string)
+}
@@ -0,0 +1,29 @@
+package golang
+
+import (
+	// stdlib
+	"os"
+	"testing"
+)
+
+func TestDetectBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		detected bool
+	}{
+		// Test binary is a Go executable built in module mode.
+		{"test binary", os.Args[0], true},
+		{"source file", "binary_test.go", false},
+		{"directory", ".", false},
+		{"missing file", "missing", false},
+	}
+
+	gp := &golangParser{}
+
+	for _, test := range tests {
+		if detected := gp.detectBinary(test.path); detected != test.detected {
+			t.Errorf("detectBinary(%s) = %v, want %v", test.name, detected, test.detected)
+		}
+	}
+}
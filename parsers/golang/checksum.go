@@ -0,0 +1,229 @@
+package golang
+
+import (
+	// stdlib
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	// local
+	"go.dev.pztrn.name/glp/cache"
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/structs"
+	"go.dev.pztrn.name/glp/workers"
+)
+
+const (
+	// Prefix of module content checksum computed by hashModuleDir.
+	checksumPrefix = "h1:"
+	// Cache bucket for modules content hashes.
+	checksumCacheBucket = "checksum"
+)
+
+// This structure represents module which content should be verified
+// against checksum. Module path and version are kept as dependency's
+// name and version are normalized for URLs composing.
+type moduleChecksum struct {
+	dependency *structs.Dependency
+	path       string
+	version    string
+}
+
+// This structure holds module directory hashing result.
+type hashResult struct {
+	hash string
+	err  error
+}
+
+// Computes module directory hash same way Go does for go.sum ("h1:"
+// hash, see golang.org/x/mod/sumdb/dirhash): SHA-256 of list of files
+// SHA-256 hashes and names prefixed with "path@version/", sorted by
+// name.
+func hashModuleDir(dir string, prefix string) (string, error) {
+	files := make([]string, 0)
+
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			rel, err1 := filepath.Rel(dir, file)
+			if err1 != nil {
+				return err1
+			}
+
+			files = append(files, filepath.ToSlash(rel))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	names := make(map[string]string, len(files))
+
+	for idx, file := range files {
+		names[prefix+"/"+file] = file
+		files[idx] = prefix + "/" + file
+	}
+
+	sort.Strings(files)
+
+	summary := sha256.New()
+
+	for _, name := range files {
+		if strings.Contains(name, "\n") {
+			return "", errors.New("file name '" + name + "' contains newline")
+		}
+
+		hash, err1 := hashFile(filepath.Join(dir, filepath.FromSlash(names[name])))
+		if err1 != nil {
+			return "", err1
+		}
+
+		fmt.Fprintf(summary, "%x  %s\n", hash, name)
+	}
+
+	return checksumPrefix + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+// Returns module directory hash. Hash is cached if it matches expected
+// checksum, so big modules aren't hashed on every run, while
+// mismatching modules are rehashed until fixed. Cache key contains
+// directory content fingerprint, so modified module is rehashed.
+// Caching is disabled with "golang.reverify" option.
+func hashModule(dir string, module string, checksum string) *hashResult {
+	useCache := !configuration.Cfg.Golang.Reverify
+
+	var key string
+
+	if useCache {
+		fingerprint, err := dirFingerprint(dir)
+		if err != nil {
+			logger.Debug("Failed to get fingerprint of '"+dir+"', hash won't be cached:", err.Error())
+
+			useCache = false
+		}
+
+		key = module + " " + dir + " " + fingerprint
+	}
+
+	var hash string
+	if useCache && cache.Get(checksumCacheBucket, key, &hash) {
+		return &hashResult{hash: hash}
+	}
+
+	hash, err := hashModuleDir(dir, module)
+	if err == nil && hash == checksum && useCache {
+		cache.Set(checksumCacheBucket, key, hash)
+	}
+
+	return &hashResult{hash: hash, err: err}
+}
+
+// Returns directory content fingerprint: SHA-256 hash of files names,
+// sizes, modes and modification times. It is much cheaper than content
+// hash as files aren't read, and changes if any file is added, removed
+// or modified.
+func dirFingerprint(dir string) (string, error) {
+	summary := sha256.New()
+
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err1 := filepath.Rel(dir, file)
+		if err1 != nil {
+			return err1
+		}
+
+		fmt.Fprintf(summary, "%q %d %s %d\n", filepath.ToSlash(rel), info.Size(), info.Mode(), info.ModTime().UnixNano())
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+// Returns SHA-256 hash of file.
+func hashFile(file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+// Verifies modules content against checksums from go.sum or executable's
+// build information. Modules without checksum aren't verified.
+func verifyModules(modules []*moduleChecksum) {
+	var wg sync.WaitGroup
+
+	for _, module := range modules {
+		if module.dependency.Checksum == "" || module.dependency.LocalPath == "" {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(module *moduleChecksum) {
+			verifyModule(module)
+			wg.Done()
+		}(module)
+	}
+
+	wg.Wait()
+}
+
+// Verifies single module content against checksum.
+func verifyModule(module *moduleChecksum) {
+	dep := module.dependency
+
+	// Only "h1:" hashes are known for now, same as for Go itself.
+	if !strings.HasPrefix(dep.Checksum, checksumPrefix) {
+		logger.Warn("Unsupported checksum", dep.Checksum, "for", dep.Name)
+		return
+	}
+
+	// Same module directory might be used in several projects.
+	result := workers.Do("checksum:"+dep.LocalPath, func() interface{} {
+		return hashModule(dep.LocalPath, module.path+"@"+module.version, dep.Checksum)
+	}).(*hashResult)
+
+	switch {
+	case result.err != nil:
+		dep.Verification = structs.VerificationFailed
+
+		logger.Warn("Failed to verify module", module.path+"@"+module.version+":", result.err.Error())
+		diagnostics.DependencyError(dep, diagnostics.CodeModuleVerificationFailed, "module content cannot be hashed: "+result.err.Error())
+	case result.hash != dep.Checksum:
+		dep.Verification = structs.VerificationMismatch
+
+		logger.Error("Module", module.path+"@"+module.version, "in", dep.LocalPath, "doesn't match checksum:", result.hash, "instead of", dep.Checksum)
+		diagnostics.DependencyError(dep, diagnostics.CodeModuleChecksumMismatch, "module content in "+dep.LocalPath+" has checksum "+result.hash+" while "+dep.Checksum+" is expected")
+	default:
+		dep.Verification = structs.VerificationOK
+	}
+}
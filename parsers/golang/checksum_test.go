@@ -0,0 +1,155 @@
+package golang
+
+import (
+	// stdlib
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	// local
+	"go.dev.pztrn.name/glp/cache"
+	"go.dev.pztrn.name/glp/configuration"
+)
+
+// Checksum of module example.com/hashtest@v1.0.0 created by
+// createModule, as reported by "go mod download -json".
+const testModuleChecksum = "h1:QFTvUrgSBqjf5Ru09dF5oSvgITRUzds7mSct17orXTU="
+
+// Creates temporary directory with module files.
+func createModule(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "glp-module-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"go.mod":      "module example.com/hashtest\n",
+		"hashtest.go": "package hashtest\n",
+		"LICENSE":     "MIT License\n",
+		"sub/sub.go":  "package sub\n",
+	}
+
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestHashModuleDir(t *testing.T) {
+	dir := createModule(t)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		module string
+		match  bool
+	}{
+		{"example.com/hashtest@v1.0.0", true},
+		// Module path and version are part of hash.
+		{"example.com/hashtest@v1.0.1", false},
+		{"example.com/other@v1.0.0", false},
+	}
+
+	for _, test := range tests {
+		hash, err := hashModuleDir(dir, test.module)
+		if err != nil {
+			t.Fatalf("hashModuleDir(%q) returned error: %v", test.module, err)
+		}
+
+		if (hash == testModuleChecksum) != test.match {
+			t.Errorf("hashModuleDir(%q) = %q, match with %q should be %v", test.module, hash, testModuleChecksum, test.match)
+		}
+	}
+
+	if _, err := hashModuleDir(filepath.Join(dir, "missing"), "example.com/hashtest@v1.0.0"); err == nil {
+		t.Errorf("hashModuleDir() of missing directory returned no error")
+	}
+}
+
+func TestHashModule(t *testing.T) {
+	dir := createModule(t)
+	defer os.RemoveAll(dir)
+
+	configuration.InitializeForTest(t, "")
+	cache.Initialize(t.TempDir(), time.Hour, false)
+	t.Cleanup(func() { cache.Initialize(t.TempDir(), time.Hour, true) })
+
+	module := "example.com/hashtest@v1.0.0"
+	license := filepath.Join(dir, "LICENSE")
+
+	// Returns count of cached hashes.
+	cached := func() int {
+		entries, err := cache.Entries()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return len(entries)
+	}
+
+	// Writes license file with passed data and modification time.
+	modify := func(data string, modified time.Time) {
+		if err := ioutil.WriteFile(license, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(license, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if result := hashModule(dir, module, testModuleChecksum); result.err != nil || result.hash != testModuleChecksum {
+		t.Fatalf("hashModule() = %q, %v, want %q", result.hash, result.err, testModuleChecksum)
+	}
+
+	if count := cached(); count != 1 {
+		t.Fatalf("got %d cached hashes after match, want 1", count)
+	}
+
+	// Modified file is detected even if it's size is same.
+	modify("MIT Licensf\n", time.Now().Add(time.Hour))
+
+	if result := hashModule(dir, module, testModuleChecksum); result.err != nil || result.hash == testModuleChecksum {
+		t.Errorf("hashModule() of modified module = %q, %v, want mismatch", result.hash, result.err)
+	}
+
+	if count := cached(); count != 1 {
+		t.Errorf("got %d cached hashes after mismatch, want 1", count)
+	}
+
+	// Restored file is hashed again and cached with new fingerprint.
+	modify("MIT License\n", time.Now().Add(2*time.Hour))
+
+	if result := hashModule(dir, module, testModuleChecksum); result.hash != testModuleChecksum {
+		t.Errorf("hashModule() of restored module = %q, want %q", result.hash, testModuleChecksum)
+	}
+
+	if count := cached(); count != 2 {
+		t.Errorf("got %d cached hashes after restore, want 2", count)
+	}
+
+	// Cache isn't used with reverify.
+	configuration.Cfg.Golang.Reverify = true
+
+	modify("MIT License\n", time.Now().Add(3*time.Hour))
+
+	if result := hashModule(dir, module, testModuleChecksum); result.hash != testModuleChecksum {
+		t.Errorf("hashModule() with reverify = %q, want %q", result.hash, testModuleChecksum)
+	}
+
+	if count := cached(); count != 2 {
+		t.Errorf("got %d cached hashes with reverify, want 2", count)
+	}
+}
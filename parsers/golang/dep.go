@@ -2,13 +2,14 @@ package golang
 
 import (
 	// stdlib
-	"log"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
 	// local
-	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/structs"
 
 	// other
@@ -47,7 +48,7 @@ func (gp *golangParser) detectDepUsage(pkgPath string) bool {
 	}
 
 	if goDepFilesFound {
-		log.Println("Project '" + pkgPath + "' is using dep for dependencies management")
+		logger.Info("Project '" + pkgPath + "' is using dep for dependencies management")
 	}
 
 	return goDepFilesFound
@@ -64,13 +65,14 @@ func (gp *golangParser) getDependenciesFromDep(pkgPath string) []*structs.Depend
 	lockFile := &depLockConfig{}
 	_, err := toml.DecodeFile(filepath.Join(pkgPath, "Gopkg.lock"), lockFile)
 	if err != nil {
-		log.Fatalln("Failed to parse dep lock file:", err.Error())
-	}
+		logger.Error("Failed to parse dep lock file:", err.Error())
+		diagnostics.Error(diagnostics.CodeDependenciesReadFailed, filepath.Join(pkgPath, "Gopkg.lock"), parent, err.Error())
 
-	if configuration.Cfg.Log.Debug {
-		log.Printf("dep lock file parsed: %+v\n", lockFile)
+		return nil
 	}
 
+	logger.Tracef("dep lock file parsed: %+v", lockFile)
+
 	// Parse dependencies.
 	for _, dep := range lockFile.Projects {
 		dependency := &structs.Dependency{
@@ -83,12 +85,12 @@ func (gp *golangParser) getDependenciesFromDep(pkgPath string) []*structs.Depend
 			Version: dep.Version,
 		}
 
-		// Name is used in URLs composing, so we should get rid of
-		// possible versioning from it, which will occur if dependency
-		// supports both go modules and other dependency managers.
-		depName := strings.Split(dependency.Name, "/")
-		if strings.HasPrefix(depName[len(depName)-1], "v") && len(depName[len(depName)-1]) == 2 {
-			dependency.Name = strings.Join(depName[:len(depName)-1], "/")
+		// Project name might contain major version suffix, which will
+		// occur if dependency supports both go modules and other
+		// dependency managers. It isn't a part of repository path used
+		// in URLs composing.
+		if repoPath := repositoryPath(dep.Name); repoPath != dep.Name {
+			dependency.RepositoryPath = repoPath
 		}
 
 		// If branch is empty - assume master.
@@ -107,9 +109,7 @@ func (gp *golangParser) getDependenciesFromDep(pkgPath string) []*structs.Depend
 
 		deps = append(deps, dependency)
 
-		if configuration.Cfg.Log.Debug {
-			log.Printf("Initial dependency structure formed: %+v\n", dependency)
-		}
+		logger.Tracef("Initial dependency structure formed: %+v", dependency)
 	}
 
 	return deps
@@ -117,10 +117,21 @@ func (gp *golangParser) getDependenciesFromDep(pkgPath string) []*structs.Depend
 
 // Tries to get package name for passed package path.
 func (gp *golangParser) getParentForDep(pkgPath string) string {
+	// Go modules projects have their name in go.mod.
+	if goMod, err := ioutil.ReadFile(filepath.Join(pkgPath, "go.mod")); err == nil {
+		for _, line := range strings.Split(string(goMod), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) >= 2 && fields[0] == "module" {
+				return strings.Trim(fields[1], "\"")
+			}
+		}
+	}
+
 	// Dep-managed projects are in 99% of cases are placed in GOPATH.
-	if strings.Contains(pkgPath, "src") {
+	if strings.Contains(pkgPath, "src/") {
 		return strings.Split(pkgPath, "src/")[1]
 	}
 
-	return ""
+	// Otherwise path is the only thing that identifies project.
+	return pkgPath
 }
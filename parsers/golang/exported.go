@@ -1,17 +1,13 @@
 package golang
 
 import (
-	// stdlib
-	"log"
-
 	// local
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/parsers/parserinterface"
 )
 
 func Initialize() (parserinterface.Interface, string) {
-	log.Println("Initializing Golang projects parser")
-
-	goDatas = make(map[string]*godata)
+	logger.Debug("Initializing Golang projects parser")
 
 	p := &golangParser{}
 	return parserinterface.Interface(p), "golang"
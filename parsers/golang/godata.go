@@ -5,29 +5,35 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
-	"log"
 	"net/http"
 	"strings"
-	"sync"
 
 	// local
-	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/cache"
+	"go.dev.pztrn.name/glp/diagnostics"
 	"go.dev.pztrn.name/glp/httpclient"
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/structs"
+	"go.dev.pztrn.name/glp/workers"
 )
 
-var (
-	goDatas      map[string]*godata
-	goDatasMutex sync.Mutex
-)
+// Cache bucket for go-import and go-source data.
+const cacheBucket = "godata"
 
 // This structure used for caching data about dependencies and prevent
 // unneeded requests.
 type godata struct {
+	ImportPrefix          string
 	SourceURLDirTemplate  string
 	SourceURLFileTemplate string
 	VCSPath               string
 	VCS                   string
+
+	// Problem that appeared while obtaining data, if any. It is
+	// reported for every dependency that uses this data.
+	failureCode     diagnostics.Code
+	failureMessage  string
+	failureSeverity diagnostics.Severity
 }
 
 // attrValue returns the attribute value for the case-insensitive key
@@ -43,23 +49,55 @@ func attrValue(attrs []xml.Attr, name string) string {
 
 // Gets go-import and go-source data and fill it in dependency.
 func getGoData(dependency *structs.Dependency) {
-	// Check if information about that dependency already cached.
-	// Use cached data if so.
-	goDatasMutex.Lock()
-	depInfo, cached := goDatas[dependency.Name+"@"+dependency.Version]
-	goDatasMutex.Unlock()
-
-	if cached {
-		dependency.VCS.SourceURLDirTemplate = depInfo.SourceURLDirTemplate
-		dependency.VCS.SourceURLFileTemplate = depInfo.SourceURLFileTemplate
-		dependency.VCS.VCS = depInfo.VCS
-		dependency.VCS.VCSPath = depInfo.VCSPath
-		return
+	// Same dependency might be used in several projects, so requests
+	// are deduplicated and executed in workers pool.
+	// Repository path is used for lookups if it differs from name.
+	name := dependency.Name
+	if dependency.RepositoryPath != "" {
+		name = dependency.RepositoryPath
+	}
+
+	depInfo := workers.Do("godata:"+name+"@"+dependency.Version, func() interface{} {
+		return fetchGoData(name)
+	}).(*godata)
+
+	if depInfo.failureCode != "" {
+		diagnostics.Add(&diagnostics.Entry{
+			Code:     depInfo.failureCode,
+			Severity: depInfo.failureSeverity,
+			Subject:  dependency.Name + "@" + dependency.Version,
+			Project:  dependency.Parent,
+			Message:  depInfo.failureMessage,
+		})
+	}
+
+	dependency.VCS.SourceURLDirTemplate = depInfo.SourceURLDirTemplate
+	dependency.VCS.SourceURLFileTemplate = depInfo.SourceURLFileTemplate
+	dependency.VCS.VCS = depInfo.VCS
+	dependency.VCS.VCSPath = depInfo.VCSPath
+
+	// Dependency might be placed in repository subdirectory, e.g. if
+	// repository contains several modules.
+	if depInfo.ImportPrefix != "" && strings.HasPrefix(name, depInfo.ImportPrefix+"/") {
+		dependency.VCS.Dir = strings.TrimPrefix(name, depInfo.ImportPrefix+"/")
+	}
+
+	logger.Tracef("go-import and go-source data parsed: %+v", dependency.VCS)
+}
+
+// Executes request for go-import and go-source data and parses it.
+func fetchGoData(name string) *godata {
+	data := &godata{}
+
+	// go-import and go-source data rarely changes, so it is cached
+	// between runs.
+	if cache.Get(cacheBucket, name, data) {
+		return data
 	}
 
 	// Dependencies are imported using URL which can be called with
 	// "?go-get=1" parameter to obtain required VCS data.
-	req, _ := http.NewRequest("GET", "http://"+dependency.Name, nil)
+	req, _ := http.NewRequest("GET", "http://"+name, nil)
 
 	q := req.URL.Query()
 	q.Add("go-get", "1")
@@ -68,7 +106,11 @@ func getGoData(dependency *structs.Dependency) {
 
 	respBody := httpclient.GET(req)
 	if respBody == nil {
-		return
+		data.failureCode = diagnostics.CodeVCSDataFetchFailed
+		data.failureMessage = "failed to get go-import and go-source data"
+		data.failureSeverity = diagnostics.SeverityWarning
+
+		return data
 	}
 
 	// HTML is hard to parse properly statically, so we will go
@@ -85,7 +127,13 @@ func getGoData(dependency *structs.Dependency) {
 		token, err := decoder.Token()
 		if err != nil {
 			if err != io.EOF {
-				log.Fatalln("Failed to parse dependency's go-source and go-import things:", err.Error())
+				logger.Error("Failed to parse dependency's go-source and go-import things:", err.Error())
+
+				data.failureCode = diagnostics.CodeVCSDataParseFailed
+				data.failureMessage = err.Error()
+				data.failureSeverity = diagnostics.SeverityError
+
+				return data
 			}
 
 			break
@@ -113,31 +161,22 @@ func getGoData(dependency *structs.Dependency) {
 		// Parse go-import data first.
 		if attrValue(e.Attr, "name") == "go-import" {
 			if f := strings.Fields(attrValue(e.Attr, "content")); len(f) == 3 {
-				dependency.VCS.VCS = f[1]
-				dependency.VCS.VCSPath = f[2]
+				data.ImportPrefix = f[0]
+				data.VCS = f[1]
+				data.VCSPath = f[2]
 			}
 		}
 
 		// Then - go-source data.
 		if attrValue(e.Attr, "name") == "go-source" {
 			if f := strings.Fields(attrValue(e.Attr, "content")); len(f) == 4 {
-				dependency.VCS.SourceURLDirTemplate = f[2]
-				dependency.VCS.SourceURLFileTemplate = f[3]
+				data.SourceURLDirTemplate = f[2]
+				data.SourceURLFileTemplate = f[3]
 			}
 		}
 	}
 
-	if configuration.Cfg.Log.Debug {
-		log.Printf("go-import and go-source data parsed: %+v\n", dependency.VCS)
-	}
+	cache.Set(cacheBucket, name, data)
 
-	// Cache parsed data.
-	goDatasMutex.Lock()
-	goDatas[dependency.Name+"@"+dependency.Version] = &godata{
-		SourceURLDirTemplate:  dependency.VCS.SourceURLDirTemplate,
-		SourceURLFileTemplate: dependency.VCS.SourceURLFileTemplate,
-		VCS:                   dependency.VCS.VCS,
-		VCSPath:               dependency.VCS.VCSPath,
-	}
-	goDatasMutex.Unlock()
+	return data
 }
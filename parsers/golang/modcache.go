@@ -0,0 +1,290 @@
+package golang
+
+import (
+	// stdlib
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	// local
+	"go.dev.pztrn.name/glp/cache"
+	"go.dev.pztrn.name/glp/httpclient"
+	"go.dev.pztrn.name/glp/logger"
+)
+
+const (
+	// Default modules proxy.
+	defaultProxy = "https://proxy.golang.org"
+	// Directory in glp's cache directory downloaded modules are
+	// extracted to.
+	modulesCacheDir = "modules"
+	// Maximum size of module's zip file, same as Go uses.
+	maxModuleZipSize = 500 << 20
+)
+
+// Returns Go modules cache directory.
+func moduleCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+
+		gopath = filepath.Join(homeDir, "go")
+	}
+
+	return filepath.Join(filepath.SplitList(gopath)[0], "pkg", "mod")
+}
+
+// Escapes module path or version for usage in module cache and proxy
+// URLs: every uppercase letter is replaced with "!" followed by
+// lowercase letter, as file systems might be case-insensitive.
+func escapeModulePath(p string) (string, error) {
+	var b strings.Builder
+
+	for _, r := range p {
+		switch {
+		case r == '!' || r >= utf8.RuneSelf:
+			return "", errors.New("invalid character '" + string(r) + "' in '" + p + "'")
+		case r >= 'A' && r <= 'Z':
+			b.WriteByte('!')
+			b.WriteRune(r + ('a' - 'A'))
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Returns module's directory name ("escaped/path@escapedVersion")
+// relative to modules cache.
+func moduleDirName(modulePath string, version string) (string, error) {
+	escapedPath, err := escapeModulePath(modulePath)
+	if err != nil {
+		return "", err
+	}
+
+	escapedVersion, err1 := escapeModulePath(version)
+	if err1 != nil {
+		return "", err1
+	}
+
+	return filepath.FromSlash(escapedPath + "@" + escapedVersion), nil
+}
+
+// Returns list of modules proxies URLs from GOPROXY environment
+// variable. "direct" is skipped as VCS fetching isn't supported and
+// "off" stops the list.
+func proxies() []string {
+	goproxy := os.Getenv("GOPROXY")
+	if goproxy == "" {
+		goproxy = defaultProxy
+	}
+
+	list := make([]string, 0)
+
+	for _, proxy := range strings.FieldsFunc(goproxy, func(r rune) bool { return r == ',' || r == '|' }) {
+		proxy = strings.TrimSpace(proxy)
+
+		if proxy == "off" {
+			break
+		}
+
+		if proxy == "" || proxy == "direct" {
+			continue
+		}
+
+		list = append(list, strings.TrimSuffix(proxy, "/"))
+	}
+
+	return list
+}
+
+// Returns true if module should not be downloaded from proxy according
+// to GONOPROXY (or GOPRIVATE) environment variable.
+func isPrivateModule(modulePath string) bool {
+	patterns := os.Getenv("GONOPROXY")
+	if patterns == "" {
+		patterns = os.Getenv("GOPRIVATE")
+	}
+
+	for _, pattern := range strings.Split(patterns, ",") {
+		pattern = strings.TrimSuffix(strings.TrimSpace(pattern), "/")
+		if pattern == "" {
+			continue
+		}
+
+		// Pattern matches path prefix with same number of elements.
+		elements := strings.Count(pattern, "/") + 1
+		prefix := modulePath
+
+		if parts := strings.SplitN(modulePath, "/", elements+1); len(parts) > elements {
+			prefix = strings.Join(parts[:elements], "/")
+		}
+
+		if matched, _ := path.Match(pattern, prefix); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Downloads module from proxy and extracts it into glp's cache
+// directory. Returns path to module's directory.
+func downloadModule(modulePath string, version string) (string, error) {
+	dirName, err := moduleDirName(modulePath, version)
+	if err != nil {
+		return "", err
+	}
+
+	moduleDir := filepath.Join(cache.Path(), modulesCacheDir, dirName)
+	if _, err1 := os.Stat(moduleDir); err1 == nil {
+		return moduleDir, nil
+	}
+
+	if isPrivateModule(modulePath) {
+		return "", errors.New("module is private (matched by GONOPROXY or GOPRIVATE), it should be downloaded into modules cache")
+	}
+
+	proxiesList := proxies()
+	if len(proxiesList) == 0 {
+		return "", errors.New("no modules proxy is configured in GOPROXY")
+	}
+
+	var data []byte
+
+	for _, proxy := range proxiesList {
+		zipURL := proxy + "/" + filepath.ToSlash(strings.Replace(dirName, "@", "/@v/", 1)) + ".zip"
+
+		logger.Debug("Downloading module", modulePath+"@"+version, "from", zipURL)
+
+		data, err = fetchModuleZip(zipURL)
+		if err == nil {
+			break
+		}
+
+		logger.Debug("Failed to download module from", zipURL+":", err.Error())
+	}
+
+	if err != nil {
+		return "", errors.New("failed to download module: " + err.Error())
+	}
+
+	if err := extractModuleZip(data, modulePath+"@"+version, moduleDir); err != nil {
+		return "", errors.New("failed to extract module: " + err.Error())
+	}
+
+	return moduleDir, nil
+}
+
+// Fetches module's zip file. Proxy might be a local directory
+// ("file://" URL).
+func fetchModuleZip(zipURL string) ([]byte, error) {
+	if strings.HasPrefix(zipURL, "file://") {
+		u, err := url.Parse(zipURL)
+		if err != nil {
+			return nil, err
+		}
+
+		return ioutil.ReadFile(filepath.FromSlash(u.Path))
+	}
+
+	req, err := http.NewRequest("GET", zipURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpclient.Fetch(req)
+}
+
+// Extracts module's zip file into passed directory. Every file in zip
+// is placed in "path@version/" directory. Files are extracted into
+// temporary directory first, so module's directory is either complete
+// or absent.
+func extractModuleZip(data []byte, prefix string, moduleDir string) error {
+	if len(data) > maxModuleZipSize {
+		return errors.New("zip file is too big")
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(moduleDir), 0755); err != nil {
+		return err
+	}
+
+	tmpDir, err1 := ioutil.TempDir(filepath.Dir(moduleDir), ".tmp-")
+	if err1 != nil {
+		return err1
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, file := range zr.File {
+		name := strings.TrimPrefix(file.Name, prefix+"/")
+		if name == file.Name || name == "" || strings.HasSuffix(name, "/") {
+			continue
+		}
+
+		if path.IsAbs(name) || strings.Contains(name, "\\") || path.Clean(name) == ".." || strings.HasPrefix(path.Clean(name), "../") {
+			return errors.New("invalid file name '" + file.Name + "'")
+		}
+
+		if err := extractZipFile(file, filepath.Join(tmpDir, filepath.FromSlash(path.Clean(name)))); err != nil {
+			return err
+		}
+	}
+
+	// Same module might be downloaded in parallel for another project.
+	if err := os.Rename(tmpDir, moduleDir); err != nil {
+		if _, err1 := os.Stat(moduleDir); err1 == nil {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Extracts single file from zip.
+func extractZipFile(file *zip.File, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	f, err1 := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err1 != nil {
+		return err1
+	}
+
+	if _, err := io.Copy(f, src); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,130 @@
+package golang
+
+import (
+	// stdlib
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Sets environment variable and returns function restoring previous
+// value.
+func setEnv(t *testing.T, key string, value string) func() {
+	t.Helper()
+
+	previous, found := os.LookupEnv(key)
+
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		if found {
+			_ = os.Setenv(key, previous)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	}
+}
+
+func TestEscapeModulePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		escaped string
+		valid   bool
+	}{
+		{"github.com/user/repo", "github.com/user/repo", true},
+		{"github.com/BurntSushi/toml", "github.com/!burnt!sushi/toml", true},
+		{"github.com/Azure/AZURE-sdk", "github.com/!azure/!a!z!u!r!e-sdk", true},
+		{"v1.0.0-RC1", "v1.0.0-!r!c1", true},
+		{"github.com/user/!repo", "", false},
+		{"github.com/user/répo", "", false},
+	}
+
+	for _, test := range tests {
+		escaped, err := escapeModulePath(test.path)
+		if (err == nil) != test.valid || escaped != test.escaped {
+			t.Errorf("escapeModulePath(%q) = %q, %v, want %q, valid %v", test.path, escaped, err, test.escaped, test.valid)
+		}
+	}
+}
+
+func TestModuleDirName(t *testing.T) {
+	tests := []struct {
+		path    string
+		version string
+		dir     string
+	}{
+		{"github.com/user/repo", "v1.0.0", "github.com/user/repo@v1.0.0"},
+		{"github.com/BurntSushi/toml", "v0.3.1", "github.com/!burnt!sushi/toml@v0.3.1"},
+		{"example.com/Module", "v1.0.0-Beta", "example.com/!module@v1.0.0-!beta"},
+		{"example.com/mödule", "v1.0.0", ""},
+		{"example.com/module", "v1.0.0-ß", ""},
+	}
+
+	for _, test := range tests {
+		dir, err := moduleDirName(test.path, test.version)
+		if dir != filepath.FromSlash(test.dir) || (err == nil) != (test.dir != "") {
+			t.Errorf("moduleDirName(%q, %q) = %q, %v, want %q", test.path, test.version, dir, err, test.dir)
+		}
+	}
+}
+
+func TestProxies(t *testing.T) {
+	tests := []struct {
+		goproxy string
+		proxies []string
+	}{
+		{"", []string{defaultProxy}},
+		{"https://proxy.golang.org,direct", []string{"https://proxy.golang.org"}},
+		{"https://goproxy.io/|https://proxy.golang.org", []string{"https://goproxy.io", "https://proxy.golang.org"}},
+		{"https://corp.example.com, direct, https://proxy.golang.org", []string{"https://corp.example.com", "https://proxy.golang.org"}},
+		{"https://corp.example.com,off,https://proxy.golang.org", []string{"https://corp.example.com"}},
+		{"off", []string{}},
+		{"direct", []string{}},
+	}
+
+	for _, test := range tests {
+		restore := setEnv(t, "GOPROXY", test.goproxy)
+
+		if proxies := proxies(); !reflect.DeepEqual(proxies, test.proxies) {
+			t.Errorf("proxies() with GOPROXY=%q = %q, want %q", test.goproxy, proxies, test.proxies)
+		}
+
+		restore()
+	}
+}
+
+func TestIsPrivateModule(t *testing.T) {
+	tests := []struct {
+		gonoproxy string
+		goprivate string
+		module    string
+		private   bool
+	}{
+		{"", "", "github.com/user/repo", false},
+		{"", "github.com/corp", "github.com/corp/repo", true},
+		{"", "github.com/corp", "github.com/corp", true},
+		{"", "github.com/corp", "github.com/corporation/repo", false},
+		{"", "*.corp.example.com", "git.corp.example.com/team/repo", true},
+		{"", "*.corp.example.com", "corp.example.com/repo", false},
+		{"", "github.com/user/*", "github.com/user/repo/v2", true},
+		{"", "example.com/a, github.com/corp/", "github.com/corp/repo", true},
+		{"github.com/other", "github.com/corp", "github.com/corp/repo", false},
+		{"github.com/other", "github.com/corp", "github.com/other/repo", true},
+	}
+
+	for _, test := range tests {
+		restoreNoProxy := setEnv(t, "GONOPROXY", test.gonoproxy)
+		restorePrivate := setEnv(t, "GOPRIVATE", test.goprivate)
+
+		if private := isPrivateModule(test.module); private != test.private {
+			t.Errorf("isPrivateModule(%q) with GONOPROXY=%q, GOPRIVATE=%q = %v, want %v",
+				test.module, test.gonoproxy, test.goprivate, private, test.private)
+		}
+
+		restoreNoProxy()
+		restorePrivate()
+	}
+}
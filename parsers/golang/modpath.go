@@ -0,0 +1,117 @@
+package golang
+
+import (
+	// stdlib
+	"regexp"
+	"strings"
+)
+
+// Splits module path into prefix and major version suffix according to
+// semantic import versioning ("/v2" and higher, ".v0" and higher for
+// gopkg.in). Adopted from golang.org/x/mod/module.SplitPathVersion.
+// Suffix is empty if path has no valid major version suffix.
+func splitPathVersion(modulePath string) (string, string) {
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		return splitGopkgIn(modulePath)
+	}
+
+	i := len(modulePath)
+	dot := false
+
+	for i > 0 && ('0' <= modulePath[i-1] && modulePath[i-1] <= '9' || modulePath[i-1] == '.') {
+		if modulePath[i-1] == '.' {
+			dot = true
+		}
+
+		i--
+	}
+
+	if i <= 1 || i == len(modulePath) || modulePath[i-1] != 'v' || modulePath[i-2] != '/' {
+		return modulePath, ""
+	}
+
+	prefix, pathMajor := modulePath[:i-2], modulePath[i-2:]
+
+	// "/v0", "/v1", "/v01" and "/v2.1" aren't major version suffixes.
+	if dot || len(pathMajor) <= 2 || pathMajor[2] == '0' || pathMajor == "/v1" {
+		return modulePath, ""
+	}
+
+	return prefix, pathMajor
+}
+
+// Splits gopkg.in module path into prefix and major version suffix
+// (".vN" with optional "-unstable").
+func splitGopkgIn(modulePath string) (string, string) {
+	i := len(modulePath)
+	if strings.HasSuffix(modulePath, "-unstable") {
+		i -= len("-unstable")
+	}
+
+	for i > 0 && '0' <= modulePath[i-1] && modulePath[i-1] <= '9' {
+		i--
+	}
+
+	if i <= 1 || modulePath[i-1] != 'v' || modulePath[i-2] != '.' {
+		return modulePath, ""
+	}
+
+	prefix, pathMajor := modulePath[:i-2], modulePath[i-2:]
+	if len(pathMajor) <= 2 || pathMajor[2] == '0' && pathMajor != ".v0" {
+		return modulePath, ""
+	}
+
+	return prefix, pathMajor
+}
+
+// Returns path of repository module (or dep project) is stored in,
+// used for go-import data and sources URLs lookups. Major version
+// suffix is a part of module path, not of repository path. gopkg.in
+// packages are redirects to GitHub: "gopkg.in/pkg.vN" is stored in
+// "github.com/go-pkg/pkg" and "gopkg.in/user/pkg.vN" in
+// "github.com/user/pkg".
+func repositoryPath(modulePath string) string {
+	prefix, pathMajor := splitPathVersion(modulePath)
+	if pathMajor == "" {
+		return modulePath
+	}
+
+	if strings.HasPrefix(prefix, "gopkg.in/") {
+		elements := strings.Split(strings.TrimPrefix(prefix, "gopkg.in/"), "/")
+
+		switch len(elements) {
+		case 1:
+			return "github.com/go-" + elements[0] + "/" + elements[0]
+		case 2:
+			return "github.com/" + elements[0] + "/" + elements[1]
+		}
+
+		return modulePath
+	}
+
+	return prefix
+}
+
+// Pseudo-version is a version of untagged commit:
+// "vX.0.0-yyyymmddhhmmss-abcdefabcdef", "vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef"
+// or "vX.Y.Z-0.yyyymmddhhmmss-abcdefabcdef", optionally with build
+// suffix (e.g. "+incompatible").
+var pseudoVersionRegexp = regexp.MustCompile(`^v[0-9]+\.(0\.0-|[0-9]+\.[0-9]+-([^+]*\.)?0\.)[0-9]{14}-([A-Za-z0-9]+)(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// Returns commit hash pseudo-version refers to or empty string if
+// version isn't a pseudo-version.
+func pseudoVersionRevision(version string) string {
+	matches := pseudoVersionRegexp.FindStringSubmatch(version)
+	if matches == nil {
+		return ""
+	}
+
+	return matches[3]
+}
+
+// Returns VCS tag for module version. "+incompatible" suffix means
+// that module v2 or higher has no go.mod file and it isn't a part of
+// tag.
+func versionTag(version string) string {
+	return strings.TrimSuffix(version, "+incompatible")
+}
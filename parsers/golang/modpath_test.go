@@ -0,0 +1,70 @@
+package golang
+
+import (
+	// stdlib
+	"testing"
+)
+
+func TestRepositoryPath(t *testing.T) {
+	tests := []struct {
+		modulePath     string
+		repositoryPath string
+	}{
+		{"github.com/user/repo", "github.com/user/repo"},
+		{"github.com/user/repo/v2", "github.com/user/repo"},
+		{"github.com/user/repo/v10", "github.com/user/repo"},
+		{"github.com/user/repo/sub/v3", "github.com/user/repo/sub"},
+		{"github.com/user/vm", "github.com/user/vm"},
+		{"github.com/user/repo/v1", "github.com/user/repo/v1"},
+		{"github.com/user/repo/v0", "github.com/user/repo/v0"},
+		{"github.com/user/repo/v02", "github.com/user/repo/v02"},
+		{"github.com/user/repo/v2.1", "github.com/user/repo/v2.1"},
+		{"gopkg.in/yaml.v2", "github.com/go-yaml/yaml"},
+		{"gopkg.in/check.v1", "github.com/go-check/check"},
+		{"gopkg.in/src-d/go-git.v4", "github.com/src-d/go-git"},
+		{"gopkg.in/pkg.v1-unstable", "github.com/go-pkg/pkg"},
+		{"gopkg.in/yaml", "gopkg.in/yaml"},
+		{"v2", "v2"},
+	}
+
+	for _, test := range tests {
+		if repositoryPath := repositoryPath(test.modulePath); repositoryPath != test.repositoryPath {
+			t.Errorf("repositoryPath(%q) = %q, want %q", test.modulePath, repositoryPath, test.repositoryPath)
+		}
+	}
+}
+
+func TestNewModuleDependency(t *testing.T) {
+	tests := []struct {
+		modulePath     string
+		version        string
+		repositoryPath string
+		revision       string
+		tag            string
+	}{
+		{"github.com/user/repo", "v1.2.0", "", "", "v1.2.0"},
+		{"github.com/user/repo/v2", "v2.0.1", "github.com/user/repo", "", "v2.0.1"},
+		{"github.com/user/repo", "v4.0.0+incompatible", "", "", "v4.0.0"},
+		{"github.com/user/repo", "v1.2.3-rc.1", "", "", "v1.2.3-rc.1"},
+		{"github.com/user/repo", "v0.0.0-20170918181015-86672fcb3f95", "", "86672fcb3f95", ""},
+		{"github.com/user/repo", "v1.2.4-0.20191112221441-2f1e5c8b3a2b", "", "2f1e5c8b3a2b", ""},
+		{"github.com/user/repo", "v1.3.0-rc.1.0.20191112221441-2f1e5c8b3a2b", "", "2f1e5c8b3a2b", ""},
+		{"github.com/user/repo", "v2.0.0-20191112221441-2f1e5c8b3a2b+incompatible", "", "2f1e5c8b3a2b", ""},
+	}
+
+	for _, test := range tests {
+		dep := newModuleDependency(test.modulePath, test.version, "", "example.com/project")
+
+		if dep.Name != test.modulePath || dep.Version != test.version {
+			t.Errorf("newModuleDependency(%q, %q) name and version = %q, %q", test.modulePath, test.version, dep.Name, dep.Version)
+		}
+
+		if dep.RepositoryPath != test.repositoryPath {
+			t.Errorf("newModuleDependency(%q, %q) repository path = %q, want %q", test.modulePath, test.version, dep.RepositoryPath, test.repositoryPath)
+		}
+
+		if dep.VCS.Revision != test.revision || dep.VCS.Tag != test.tag {
+			t.Errorf("newModuleDependency(%q, %q) revision and tag = %q, %q, want %q, %q", test.modulePath, test.version, dep.VCS.Revision, dep.VCS.Tag, test.revision, test.tag)
+		}
+	}
+}
@@ -4,12 +4,14 @@ import (
 	// stdlib
 
 	"bufio"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
 
 	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/golist"
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/structs"
 )
 
@@ -26,7 +28,7 @@ func (gp *golangParser) detectModulesUsage(pkgPath string) bool {
 	}
 
 	if goModulesFileFound {
-		log.Println("Project '" + pkgPath + "' is using Go modules for dependencies management")
+		logger.Info("Project '" + pkgPath + "' is using Go modules for dependencies management")
 	}
 
 	return goModulesFileFound
@@ -39,10 +41,11 @@ func (gp *golangParser) getDependenciesFromModules(pkgPath string) []*structs.De
 	// Try to figure out parent package name for all dependencies.
 	parent := gp.getParentForDep(pkgPath)
 
-	// Get GOPATH for future dependency path composing.
-	gopath, found := os.LookupEnv("GOPATH")
-	if !found {
-		log.Fatalln("Go modules project found but no GOPATH environment variable defined. Cannot continue.")
+	// Get modules cache directory for future dependency path
+	// composing.
+	cacheDir := moduleCacheDir()
+	if cacheDir == "" {
+		logger.Fatal("Go modules project found but modules cache directory cannot be determined. Set GOMODCACHE or GOPATH environment variable.")
 	}
 
 	// To get really all dependencies we should use go.sum file.
@@ -50,72 +53,148 @@ func (gp *golangParser) getDependenciesFromModules(pkgPath string) []*structs.De
 
 	f, err := os.Open(filePath)
 	if err != nil {
-		log.Println("Failed to open go.sum file for reading:", err.Error())
+		logger.Error("Failed to open go.sum file for reading:", err.Error())
+		diagnostics.Error(diagnostics.CodeDependenciesReadFailed, filePath, parent, err.Error())
+
 		return nil
 	}
+	defer f.Close()
+
+	// go.sum lists every module that was ever needed, including
+	// test-only and tools dependencies, so report might be restricted
+	// to modules that are really compiled into binaries.
+	var linkedModules map[string]bool
+
+	if golist.LinkedOnly() {
+		linkedModules, err = golist.LinkedModules(pkgPath)
+		if err != nil {
+			logger.Error("Failed to list modules linked into binaries, reporting all dependencies:", err.Error())
+			diagnostics.Error(diagnostics.CodePackagesListFailed, pkgPath, parent, err.Error())
+		}
+	}
 
 	// We do not need multiple lines of dependencies in reports which
 	// describes same name and version.
+	modulesList := make([]*moduleChecksum, 0)
 	createdDeps := make(map[string]bool)
 
+	// Module's content checksums, from lines without "/go.mod" suffix.
+	// Modules with only "/go.mod" line were needed only for
+	// dependencies graph resolving and never downloaded.
+	checksums := make(map[string]string)
+
 	// Read file data line by line.
 	gosum := bufio.NewScanner(f)
 	gosum.Split(bufio.ScanLines)
 
 	for gosum.Scan() {
 		depLine := strings.Split(gosum.Text(), " ")
+		if len(depLine) < 3 {
+			continue
+		}
 
 		// Version should be cleared out from possible "/go.mod"
 		// substring.
 		version := strings.Split(depLine[1], "/")[0]
 
+		if !strings.HasSuffix(depLine[1], "/go.mod") {
+			checksums[depLine[0]+"@"+version] = depLine[2]
+		}
+
 		// Check if we've already processed that dependency.
-		_, processed := createdDeps[depLine[0]+"@"+version]
-		if processed {
+		if createdDeps[depLine[0]+"@"+version] {
+			continue
+		}
+
+		createdDeps[depLine[0]+"@"+version] = true
+		modulesList = append(modulesList, &moduleChecksum{path: depLine[0], version: version})
+	}
+
+	modules := make([]*moduleChecksum, 0, len(modulesList))
+
+	for _, module := range modulesList {
+		key := module.path + "@" + module.version
+
+		if linkedModules != nil && !linkedModules[key] {
+			logger.Debug("Skipping dependency", key, "as it isn't linked into binaries")
 			continue
 		}
 
 		// Go modules present on disk either in vendor or in GOPATH/pkg
 		// directory. But vendor here should not be trusted because it
-		// might contain old versions.
-		dependencyPath := filepath.Join(gopath, "pkg", "mod", depLine[0]+"@"+version)
+		// might contain old versions. Modules cache stores paths with
+		// uppercase letters escaped.
+		dirName, err := moduleDirName(module.path, module.version)
+		if err != nil {
+			logger.Warn("Invalid module", key+":", err.Error())
+			diagnostics.Warning(diagnostics.CodeDependenciesReadFailed, key, parent, "invalid module path or version in go.sum: "+err.Error())
 
-		// Check if this module exists on disk. Absence means that it
-		// isn't actually used and just pollute go.sum.
-		if _, err := os.Stat(dependencyPath); err != nil {
 			continue
 		}
 
-		dependency := &structs.Dependency{
-			LocalPath: dependencyPath,
-			Name:      depLine[0],
-			Parent:    parent,
-			Version:   version,
-		}
+		dependencyPath := filepath.Join(cacheDir, dirName)
 
-		// Name is used in URLs composing, so we should get rid of
-		// possible versioning from it, which will occur if dependency
-		// supports both go modules and other dependency managers.
-		depName := strings.Split(dependency.Name, "/")
-		if strings.HasPrefix(depName[len(depName)-1], "v") && len(depName[len(depName)-1]) == 2 {
-			dependency.Name = strings.Join(depName[:len(depName)-1], "/")
-		}
+		if _, err := os.Stat(dependencyPath); err != nil {
+			// Module that was never downloaded isn't used.
+			if checksums[key] == "" {
+				continue
+			}
 
-		// Version might contain "+incompatible", which might break
-		// license URL generation.
-		if strings.Contains(dependency.Version, "incompatible") {
-			dependency.Version = strings.Split(dependency.Version, "+incompat")[0]
+			dependencyPath = ""
 		}
 
-		// As we're using specific version - we should assume it as
-		// branch name.
-		dependency.VCS.Branch = dependency.Version
+		module.dependency = newModuleDependency(module.path, module.version, dependencyPath, parent)
+		module.dependency.Checksum = checksums[key]
 
-		deps = append(deps, dependency)
+		deps = append(deps, module.dependency)
+		modules = append(modules, module)
 
-		// Mark dependency as processed.
-		createdDeps[depLine[0]+"@"+version] = true
+		// Modules compiled into binaries should be in cache, others
+		// might be just a go.sum pollution.
+		if dependencyPath == "" {
+			message := "module isn't in modules cache (" + filepath.Join(cacheDir, dirName) + "), run 'go mod download' to get it"
+
+			logger.Warn("Module", key, "isn't in modules cache")
+
+			if linkedModules != nil {
+				diagnostics.DependencyError(module.dependency, diagnostics.CodeModuleNotFound, message)
+			} else {
+				diagnostics.DependencyWarning(module.dependency, diagnostics.CodeModuleNotFound, message)
+			}
+		}
 	}
 
+	// Module's content in cache might be modified, so it is verified
+	// to prove that licenses are read from content project is built
+	// with.
+	verifyModules(modules)
+
 	return deps
 }
+
+// Creates dependency for module with passed path and version placed in
+// passed directory.
+func newModuleDependency(modulePath string, version string, localPath string, parent string) *structs.Dependency {
+	dependency := &structs.Dependency{
+		LocalPath: localPath,
+		Name:      modulePath,
+		Parent:    parent,
+		Version:   version,
+	}
+
+	// Module path identifies dependency, but major version suffix
+	// isn't a part of repository path used in URLs composing.
+	if repoPath := repositoryPath(modulePath); repoPath != modulePath {
+		dependency.RepositoryPath = repoPath
+	}
+
+	// Pseudo-versions refer to untagged commits, other versions are
+	// tags.
+	if revision := pseudoVersionRevision(version); revision != "" {
+		dependency.VCS.Revision = revision
+	} else {
+		dependency.VCS.Tag = versionTag(version)
+	}
+
+	return dependency
+}
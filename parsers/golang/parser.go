@@ -12,6 +12,10 @@ const (
 	// Package managers names. Used in Detect() for flavor returning.
 	packageManagerGoMod = "go mod"
 	packageManagerDep   = "dep"
+
+	// Flavor for compiled executables, dependencies are taken from
+	// embedded build information.
+	flavorBinary = "binary"
 )
 
 // This structure responsible for parsing projects that written in Go.
@@ -32,6 +36,11 @@ func (gp *golangParser) Detect(pkgPath string) (bool, string) {
 		return true, packageManagerDep
 	}
 
+	isBinary := gp.detectBinary(pkgPath)
+	if isBinary {
+		return true, flavorBinary
+	}
+
 	return false, ""
 }
 
@@ -44,6 +53,8 @@ func (gp *golangParser) GetDependencies(flavor string, pkgPath string) []*struct
 		deps = gp.getDependenciesFromDep(pkgPath)
 	case packageManagerGoMod:
 		deps = gp.getDependenciesFromModules(pkgPath)
+	case flavorBinary:
+		deps = gp.getDependenciesFromBinary(pkgPath)
 	}
 
 	// Return early if no dependencies was found.
@@ -52,7 +63,8 @@ func (gp *golangParser) GetDependencies(flavor string, pkgPath string) []*struct
 	}
 
 	// For every dependency we should get additional data - go-import
-	// and go-source. Asynchronously.
+	// and go-source. Asynchronously, requests itself are limited by
+	// workers pool.
 	var wg sync.WaitGroup
 
 	for _, dep := range deps {
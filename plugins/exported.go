@@ -0,0 +1,150 @@
+package plugins
+
+import (
+	// stdlib
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+)
+
+const (
+	// ParserPrefix is a prefix of external parsers executables names.
+	ParserPrefix = "glp-parser-"
+	// OutputterPrefix is a prefix of external outputters executables
+	// names.
+	OutputterPrefix = "glp-output-"
+)
+
+// Executable represents external executable that extends glp. It
+// receives JSON request on stdin and writes response to stdout.
+type Executable struct {
+	// Name is an executable name without prefix (and extension on
+	// Windows). It is used as parser or outputter name.
+	Name string
+	// Path is a path to executable.
+	Path string
+}
+
+// Discover looks for executables with passed prefix in directories
+// listed in PATH environment variable. If several executables have same
+// name - first one found is used, like shell does. Result is sorted by
+// name.
+func Discover(prefix string) []*Executable {
+	found := make(map[string]*Executable)
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, file := range files {
+			name := file.Name()
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+
+			// Symlinks are followed.
+			fi, err := os.Stat(filepath.Join(dir, name))
+			if err != nil || fi.IsDir() || !isExecutable(fi) {
+				continue
+			}
+
+			name = strings.TrimPrefix(name, prefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+
+			if name == "" {
+				continue
+			}
+
+			if _, exists := found[name]; exists {
+				continue
+			}
+
+			logger.Debug("Found external executable '" + file.Name() + "' in " + dir)
+
+			found[name] = &Executable{Name: name, Path: filepath.Join(dir, file.Name())}
+		}
+	}
+
+	result := make([]*Executable, 0, len(found))
+	for _, executable := range found {
+		result = append(result, executable)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
+
+// Call executes executable with passed request and decodes it's JSON
+// response.
+func (e *Executable) Call(request interface{}, response interface{}) error {
+	var stdout bytes.Buffer
+
+	if err := e.Stream(request, &stdout); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return errors.New("failed to parse '" + e.Path + "' response: " + err.Error())
+	}
+
+	return nil
+}
+
+// Stream executes executable with passed request and copies it's output
+// into passed writer as is.
+func (e *Executable) Stream(request interface{}, w io.Writer) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return errors.New("failed to prepare request for '" + e.Path + "': " + err.Error())
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(e.Path)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	logger.Trace("Executing", e.Path, "with request:", string(data))
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message != "" {
+			return errors.New("'" + e.Path + "' failed: " + err.Error() + ": " + message)
+		}
+
+		return errors.New("'" + e.Path + "' failed: " + err.Error())
+	}
+
+	return nil
+}
+
+// Returns true if file can be executed.
+func isExecutable(file os.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(file.Name()))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+
+	return file.Mode()&0111 != 0
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	// stdlib
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/spdx"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Violation describes single dependency that violates licensing
+// policy.
+type Violation struct {
+	// Dependency is a violating dependency.
+	Dependency *structs.Dependency
+	// Reason is a human-readable violation reason.
+	Reason string
+}
+
+// Check checks passed dependencies against policy from configuration
+// file and returns found violations.
+func Check(deps []*structs.Dependency) []*Violation {
+	cfg := configuration.Cfg.Policy
+
+	allowed := toSet(cfg.Allowed)
+	denied := toSet(cfg.Denied)
+
+	var violations []*Violation
+
+	acceptable := func(license string) bool {
+		return !denied[license] && (len(allowed) == 0 || allowed[license])
+	}
+
+	for _, dep := range deps {
+		// Licenses of bundled code that is (or might be) compiled
+		// into binaries should be acceptable too.
+		for _, nested := range dep.License.Nested {
+			if nested.Usage == structs.UsageNotLinked {
+				continue
+			}
+
+			if expression, err := spdx.Parse(nested.Expression); err != nil || !expression.Satisfied(acceptable) {
+				violations = append(violations, &Violation{Dependency: dep, Reason: "license " + nested.Expression + " of subdirectory " + nested.Path + " isn't satisfied by policy"})
+			}
+		}
+
+		licenseName := dep.License.Name
+
+		// Uncertain detection result still should be checked against
+		// policy, allowing unknown licenses only waives the review.
+		if licenseName == structs.LicenseNeedsReview {
+			if !cfg.AllowUnknown {
+				violations = append(violations, &Violation{Dependency: dep, Reason: "license detection confidence is too low, license needs review"})
+			}
+
+			licenseName = detectedLicense(dep)
+			if licenseName == "" {
+				continue
+			}
+		}
+
+		// Dependencies licensed under choice of licenses are fine if
+		// any of licenses is acceptable.
+		if expression, err := spdx.Parse(dep.License.Expression); err == nil && expression.Operator != "" {
+			if !expression.Satisfied(acceptable) {
+				violations = append(violations, &Violation{Dependency: dep, Reason: "license expression " + expression.String() + " isn't satisfied by policy (unacceptable: " + strings.Join(unacceptable(expression, acceptable), ", ") + ")"})
+			}
+
+			continue
+		}
+
+		switch {
+		case licenseName == "" || licenseName == "Unknown":
+			if !cfg.AllowUnknown {
+				violations = append(violations, &Violation{Dependency: dep, Reason: "license is unknown"})
+			}
+		case denied[licenseName]:
+			violations = append(violations, &Violation{Dependency: dep, Reason: "license " + licenseName + " is denied"})
+		case len(allowed) > 0 && !allowed[licenseName]:
+			violations = append(violations, &Violation{Dependency: dep, Reason: "license " + licenseName + " is not in allowed list"})
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Dependency.Name < violations[j].Dependency.Name
+	})
+
+	return violations
+}
+
+// Returns license that was detected for dependency even if it's
+// confidence is too low: first license of expression or most confident
+// candidate.
+func detectedLicense(dep *structs.Dependency) string {
+	if expression, err := spdx.Parse(dep.License.Expression); err == nil {
+		return expression.Licenses()[0]
+	}
+
+	if len(dep.License.Candidates) > 0 {
+		return dep.License.Candidates[0].Name
+	}
+
+	return ""
+}
+
+// Returns unacceptable licenses used in expression.
+func unacceptable(expression *spdx.Expression, acceptable func(license string) bool) []string {
+	licenses := make([]string, 0)
+
+	for _, license := range expression.Licenses() {
+		if !acceptable(license) {
+			licenses = append(licenses, license)
+		}
+	}
+
+	return licenses
+}
+
+// Converts list to set.
+func toSet(list []string) map[string]bool {
+	set := make(map[string]bool, len(list))
+	for _, item := range list {
+		set[item] = true
+	}
+
+	return set
+}
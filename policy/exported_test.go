@@ -0,0 +1,112 @@
+package policy
+
+import (
+	// stdlib
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		license    structs.License
+		violations int
+	}{
+		{
+			name:    "allowed license",
+			policy:  "  allowed: [MIT]\n",
+			license: structs.License{Name: "MIT", Expression: "MIT"},
+		},
+		{
+			name:       "not allowed license",
+			policy:     "  allowed: [MIT]\n",
+			license:    structs.License{Name: "GPL-3.0", Expression: "GPL-3.0"},
+			violations: 1,
+		},
+		{
+			name:       "denied license",
+			policy:     "  denied: [GPL-3.0]\n",
+			license:    structs.License{Name: "GPL-3.0", Expression: "GPL-3.0"},
+			violations: 1,
+		},
+		{
+			name:       "unknown license",
+			policy:     "  allowed: [MIT]\n",
+			license:    structs.License{Name: "Unknown"},
+			violations: 1,
+		},
+		{
+			name:    "allowed unknown license",
+			policy:  "  allowed: [MIT]\n  allow_unknown: true\n",
+			license: structs.License{Name: "Unknown"},
+		},
+		{
+			name:    "choice with allowed license",
+			policy:  "  allowed: [MIT]\n",
+			license: structs.License{Name: "MIT", Expression: "Apache-2.0 OR MIT"},
+		},
+		{
+			name:       "all licenses should be allowed",
+			policy:     "  allowed: [Apache-2.0]\n",
+			license:    structs.License{Name: "Apache-2.0", Expression: "Apache-2.0 AND GPL-3.0"},
+			violations: 1,
+		},
+		{
+			name:       "license needs review",
+			policy:     "  allowed: [MIT]\n",
+			license:    structs.License{Name: structs.LicenseNeedsReview, Expression: "MIT"},
+			violations: 1,
+		},
+		{
+			name:    "allowed license needs review with allowed unknown",
+			policy:  "  allowed: [MIT]\n  allow_unknown: true\n",
+			license: structs.License{Name: structs.LicenseNeedsReview, Expression: "MIT"},
+		},
+		{
+			name:       "denied license needs review with allowed unknown",
+			policy:     "  denied: [GPL-3.0]\n  allow_unknown: true\n",
+			license:    structs.License{Name: structs.LicenseNeedsReview, Expression: "GPL-3.0"},
+			violations: 1,
+		},
+		{
+			name:   "denied candidate needs review with allowed unknown",
+			policy: "  denied: [GPL-3.0]\n  allow_unknown: true\n",
+			license: structs.License{
+				Name:       structs.LicenseNeedsReview,
+				Candidates: []*structs.LicenseCandidate{{Name: "GPL-3.0", Confidence: 0.5}},
+			},
+			violations: 1,
+		},
+		{
+			name:    "not linked nested license",
+			policy:  "  allowed: [MIT]\n",
+			license: structs.License{Name: "MIT", Expression: "MIT", Nested: []*structs.NestedLicense{{Expression: "GPL-3.0", Path: "a", Usage: structs.UsageNotLinked}}},
+		},
+		{
+			name:       "linked nested license",
+			policy:     "  allowed: [MIT]\n",
+			license:    structs.License{Name: "MIT", Expression: "MIT", Nested: []*structs.NestedLicense{{Expression: "GPL-3.0", Path: "a", Usage: structs.UsageLinked}}},
+			violations: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration.InitializeForTest(t, "policy:\n"+test.policy)
+
+			violations := Check([]*structs.Dependency{{Name: "example.com/dep", License: test.license}})
+			if len(violations) != test.violations {
+				reasons := make([]string, 0, len(violations))
+				for _, violation := range violations {
+					reasons = append(reasons, violation.Reason)
+				}
+
+				t.Errorf("got %d violations %q, want %d", len(violations), reasons, test.violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package projecter
+
+import (
+	// stdlib
+	"errors"
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/semver"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+const (
+	// AggregateNone disables aggregation, every project's dependency
+	// will be in report.
+	AggregateNone = "none"
+	// AggregateVersion merges dependencies with same name and version
+	// from different projects.
+	AggregateVersion = "version"
+	// AggregateModule merges dependencies with same name from different
+	// projects regardless of version.
+	AggregateModule = "module"
+)
+
+// CheckAggregationMode returns error if passed aggregation mode is
+// unknown.
+func CheckAggregationMode(mode string) error {
+	switch mode {
+	case AggregateNone, AggregateVersion, AggregateModule:
+		return nil
+	}
+
+	return errors.New("unknown aggregation mode '" + mode + "', should be one of: none, version, module")
+}
+
+// Severity of dependency's content verification result, merged
+// dependency gets the worst result of merged ones.
+var verificationSeverity = map[string]int{
+	"":                           0,
+	structs.VerificationOK:       1,
+	structs.VerificationFailed:   2,
+	structs.VerificationMismatch: 3,
+}
+
+// Aggregates dependencies from all projects using passed mode. Passed
+// dependencies aren't modified, merged dependencies are copies.
+func aggregate(deps []*structs.Dependency, mode string) []*structs.Dependency {
+	if mode == AggregateNone || mode == "" {
+		return deps
+	}
+
+	logger.Info("Aggregating dependencies, mode:", mode)
+
+	// Group dependencies, preserving order of first appearance.
+	var keys []string
+
+	groups := make(map[string][]*structs.Dependency)
+
+	for _, dep := range deps {
+		key := dep.Name
+
+		// Versions licensed differently are kept in separate rows, so
+		// every row states license of all its versions.
+		switch mode {
+		case AggregateVersion:
+			key += "@" + dep.Version
+		case AggregateModule:
+			key += " " + dep.License.Name
+		}
+
+		if _, found := groups[key]; !found {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], dep)
+	}
+
+	aggregated := make([]*structs.Dependency, 0, len(keys))
+
+	for _, key := range keys {
+		aggregated = append(aggregated, mergeDependencies(groups[key], mode))
+	}
+
+	if mode == AggregateModule {
+		warnLicenseDifferences(aggregated)
+	}
+
+	logger.Infof("Aggregated %d dependencies into %d", len(deps), len(aggregated))
+
+	return aggregated
+}
+
+// Merges group of dependencies into one.
+func mergeDependencies(group []*structs.Dependency, mode string) *structs.Dependency {
+	// Latest version is a base for merged dependency as it's data (like
+	// license) is most actual.
+	base := group[0]
+
+	for _, dep := range group[1:] {
+		if semver.Compare(dep.Version, base.Version) > 0 {
+			base = dep
+		}
+	}
+
+	merged := *base
+	merged.Parents = uniqueSorted(group, func(dep *structs.Dependency) string { return dep.Parent })
+	merged.Parent = strings.Join(merged.Parents, ",")
+	merged.License.Nested = mergeNestedLicenses(base, group)
+
+	// Problem with content of any merged dependency should stay in
+	// report.
+	for _, dep := range group {
+		if verificationSeverity[dep.Verification] > verificationSeverity[merged.Verification] {
+			merged.Checksum = dep.Checksum
+			merged.Verification = dep.Verification
+		}
+	}
+
+	if mode != AggregateModule {
+		return &merged
+	}
+
+	merged.Versions = uniqueSorted(group, func(dep *structs.Dependency) string { return dep.Version })
+	sort.Slice(merged.Versions, func(i, j int) bool { return semver.Compare(merged.Versions[i], merged.Versions[j]) < 0 })
+	merged.Version = strings.Join(merged.Versions, ",")
+
+	return &merged
+}
+
+// Warns about modules which versions are licensed differently, as
+// license change should be checked manually. Every module's row is
+// compared with row containing latest version.
+func warnLicenseDifferences(aggregated []*structs.Dependency) {
+	var names []string
+
+	rows := make(map[string][]*structs.Dependency)
+
+	for _, dep := range aggregated {
+		if _, found := rows[dep.Name]; !found {
+			names = append(names, dep.Name)
+		}
+
+		rows[dep.Name] = append(rows[dep.Name], dep)
+	}
+
+	// Returns latest version of row.
+	latestVersion := func(dep *structs.Dependency) string {
+		return dep.Versions[len(dep.Versions)-1]
+	}
+
+	for _, name := range names {
+		if len(rows[name]) < 2 {
+			continue
+		}
+
+		latest := rows[name][0]
+
+		for _, dep := range rows[name][1:] {
+			if semver.Compare(latestVersion(dep), latestVersion(latest)) > 0 {
+				latest = dep
+			}
+		}
+
+		for _, dep := range rows[name] {
+			if dep == latest {
+				continue
+			}
+
+			diagnostics.Warning(diagnostics.CodeLicenseDiffersBetweenVersions, dep.Name, dep.Parent,
+				"versions "+dep.Version+" are licensed under "+dep.License.Name+" while "+latestVersion(latest)+" is licensed under "+latest.License.Name)
+		}
+	}
+}
+
+// Returns sorted list of unique non-empty values obtained from group.
+func uniqueSorted(group []*structs.Dependency, value func(dep *structs.Dependency) string) []string {
+	seen := make(map[string]bool)
+	values := make([]string, 0, len(group))
+
+	for _, dep := range group {
+		v := value(dep)
+		if v == "" || seen[v] {
+			continue
+		}
+
+		seen[v] = true
+
+		values = append(values, v)
+	}
+
+	sort.Strings(values)
+
+	return values
+}
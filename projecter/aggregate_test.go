@@ -0,0 +1,154 @@
+package projecter
+
+import (
+	// stdlib
+	"reflect"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+func TestMergeDependencies(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		group        []*structs.Dependency
+		version      string
+		parent       string
+		license      string
+		checksum     string
+		verification string
+	}{
+		{
+			name: "same version",
+			mode: AggregateVersion,
+			group: []*structs.Dependency{
+				{Name: "example.com/dep", Version: "v1.0.0", Parent: "b", License: structs.License{Name: "MIT"}, Checksum: "h1:a", Verification: structs.VerificationOK},
+				{Name: "example.com/dep", Version: "v1.0.0", Parent: "a", License: structs.License{Name: "MIT"}, Checksum: "h1:a", Verification: structs.VerificationOK},
+			},
+			version:      "v1.0.0",
+			parent:       "a,b",
+			license:      "MIT",
+			checksum:     "h1:a",
+			verification: structs.VerificationOK,
+		},
+		{
+			name: "latest version is a base",
+			mode: AggregateModule,
+			group: []*structs.Dependency{
+				{Name: "example.com/dep", Version: "v1.10.0", Parent: "a", License: structs.License{Name: "MIT"}},
+				{Name: "example.com/dep", Version: "v1.9.0", Parent: "b", License: structs.License{Name: "MIT"}},
+			},
+			version: "v1.9.0,v1.10.0",
+			parent:  "a,b",
+			license: "MIT",
+		},
+		{
+			name: "worst verification is kept",
+			mode: AggregateModule,
+			group: []*structs.Dependency{
+				{Name: "example.com/dep", Version: "v1.2.0", Parent: "a", License: structs.License{Name: "MIT"}, Checksum: "h1:c", Verification: structs.VerificationOK},
+				{Name: "example.com/dep", Version: "v1.0.0", Parent: "b", License: structs.License{Name: "MIT"}, Checksum: "h1:a", Verification: structs.VerificationMismatch},
+				{Name: "example.com/dep", Version: "v1.1.0", Parent: "c", License: structs.License{Name: "MIT"}, Checksum: "h1:b", Verification: structs.VerificationFailed},
+			},
+			version:      "v1.0.0,v1.1.0,v1.2.0",
+			parent:       "a,b,c",
+			license:      "MIT",
+			checksum:     "h1:a",
+			verification: structs.VerificationMismatch,
+		},
+		{
+			name: "verification result is kept over missing one",
+			mode: AggregateModule,
+			group: []*structs.Dependency{
+				{Name: "example.com/dep", Version: "v1.1.0", Parent: "a", License: structs.License{Name: "MIT"}},
+				{Name: "example.com/dep", Version: "v1.0.0", Parent: "b", License: structs.License{Name: "MIT"}, Checksum: "h1:a", Verification: structs.VerificationOK},
+			},
+			version:      "v1.0.0,v1.1.0",
+			parent:       "a,b",
+			license:      "MIT",
+			checksum:     "h1:a",
+			verification: structs.VerificationOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged := mergeDependencies(test.group, test.mode)
+
+			if merged.Version != test.version || merged.Parent != test.parent || merged.License.Name != test.license {
+				t.Errorf("version, parent, license = %q, %q, %q, want %q, %q, %q",
+					merged.Version, merged.Parent, merged.License.Name, test.version, test.parent, test.license)
+			}
+
+			if merged.Checksum != test.checksum || merged.Verification != test.verification {
+				t.Errorf("checksum, verification = %q, %q, want %q, %q",
+					merged.Checksum, merged.Verification, test.checksum, test.verification)
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	deps := []*structs.Dependency{
+		{Name: "example.com/a", Version: "v1.0.0", Parent: "p1"},
+		{Name: "example.com/b", Version: "v1.0.0", Parent: "p1"},
+		{Name: "example.com/a", Version: "v1.1.0", Parent: "p2"},
+		{Name: "example.com/a", Version: "v1.0.0", Parent: "p2"},
+	}
+
+	tests := []struct {
+		mode     string
+		versions []string
+	}{
+		{AggregateNone, []string{"example.com/a@v1.0.0", "example.com/b@v1.0.0", "example.com/a@v1.1.0", "example.com/a@v1.0.0"}},
+		{AggregateVersion, []string{"example.com/a@v1.0.0", "example.com/b@v1.0.0", "example.com/a@v1.1.0"}},
+		{AggregateModule, []string{"example.com/a@v1.0.0,v1.1.0", "example.com/b@v1.0.0"}},
+	}
+
+	for _, test := range tests {
+		diagnostics.Initialize("")
+
+		versions := make([]string, 0)
+		for _, dep := range aggregate(deps, test.mode) {
+			versions = append(versions, dep.Name+"@"+dep.Version)
+		}
+
+		if !reflect.DeepEqual(versions, test.versions) {
+			t.Errorf("aggregate(%q) = %q, want %q", test.mode, versions, test.versions)
+		}
+	}
+
+	if deps[0].Parent != "p1" {
+		t.Errorf("aggregate() modified passed dependency")
+	}
+}
+
+func TestAggregateLicenseDiffers(t *testing.T) {
+	diagnostics.Initialize("")
+
+	deps := []*structs.Dependency{
+		{Name: "example.com/dep", Version: "v2.0.0", Parent: "a", License: structs.License{Name: "Apache-2.0"}},
+		{Name: "example.com/dep", Version: "v1.0.0", Parent: "b", License: structs.License{Name: "MIT"}},
+		{Name: "example.com/dep", Version: "v1.0.0", Parent: "c", License: structs.License{Name: "MIT"}},
+		{Name: "example.com/dep", Version: "v1.1.0", Parent: "d", License: structs.License{Name: "MIT"}},
+		{Name: "example.com/dep", Version: "v0.1.0", Parent: "e", License: structs.License{Name: "BSD-3-Clause"}},
+	}
+
+	rows := make([]string, 0)
+	for _, dep := range aggregate(deps, AggregateModule) {
+		rows = append(rows, dep.Version+" "+dep.Parent+" "+dep.License.Name)
+	}
+
+	want := []string{"v2.0.0 a Apache-2.0", "v1.0.0,v1.1.0 b,c,d MIT", "v0.1.0 e BSD-3-Clause"}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("aggregate() = %q, want %q", rows, want)
+	}
+
+	// Every differently licensed row is compared with latest version.
+	if warnings := diagnostics.Entries(); len(warnings) != 2 {
+		t.Errorf("got %d warnings, want 2", len(warnings))
+	}
+}
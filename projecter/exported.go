@@ -2,37 +2,34 @@ package projecter
 
 import (
 	// stdlib
-	"log"
 	"strings"
 	"sync"
 
 	// local
-	"go.dev.pztrn.name/glp/configuration"
-	"go.dev.pztrn.name/glp/outputters"
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/structs"
 )
 
 var (
 	packages []string
 
-	outputFormat string
-	outputFile   string
+	aggregationMode string
 
 	projects      map[string]*Project
 	projectsMutex sync.RWMutex
 )
 
-// Initialize initializes package.
-func Initialize(pkgs string, outFormat string, outFile string) {
-	log.Println("Initializing projects handler...")
+// Initialize initializes package. Aggregation is a mode of merging
+// same dependencies from different projects, see Aggregate* constants.
+func Initialize(pkgs string, aggregation string) {
+	logger.Debug("Initializing projects handler...")
 
 	packages = strings.Split(pkgs, ",")
 	projects = make(map[string]*Project)
 
-	outputFormat = outFormat
-	outputFile = outFile
+	aggregationMode = aggregation
 
-	log.Println("Packages list that was passed:", packages)
+	logger.Info("Packages list that was passed:", packages)
 }
 
 // GetProject returns project by it's path.
@@ -48,8 +45,9 @@ func GetProject(path string) *Project {
 	return prj
 }
 
-// Parse starts projects parsing.
-func Parse() {
+// Parse starts projects parsing. It returns dependencies from all
+// projects, aggregated if requested.
+func Parse() []*structs.Dependency {
 	// Create project for every passed package.
 	// This is done in main goroutine and therefore no mutex is used.
 	for _, pkgPath := range packages {
@@ -57,9 +55,7 @@ func Parse() {
 		projects[pkgPath] = prj
 	}
 
-	if configuration.Cfg.Log.Debug {
-		log.Printf("Projects generated: %+v\n", projects)
-	}
+	logger.Tracef("Projects generated: %+v", projects)
 
 	// We should start asynchronous projects parsing.
 	var wg sync.WaitGroup
@@ -82,7 +78,9 @@ func Parse() {
 		deps = append(deps, prj.GetDeps()...)
 	}
 
-	outputters.Write(outputFormat, outputFile, deps)
+	deps = aggregate(deps, aggregationMode)
+
+	logger.Info("Parsing done")
 
-	log.Println("Parsing done")
+	return deps
 }
@@ -0,0 +1,296 @@
+package projecter
+
+import (
+	// stdlib
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/copyrights"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/spdx"
+	"go.dev.pztrn.name/glp/structs"
+
+	// other
+	"gopkg.in/src-d/go-license-detector.v3/licensedb"
+	"gopkg.in/src-d/go-license-detector.v3/licensedb/api"
+	"gopkg.in/src-d/go-license-detector.v3/licensedb/filer"
+)
+
+// This structure holds license scan result for single dependency
+// directory. It is shared between all dependencies placed in that
+// directory.
+type licenseScanResult struct {
+	candidates []*structs.LicenseCandidate
+	confidence float32
+	copyrights []string
+	expression string
+	file       string
+	method     string
+	name       string
+	nested     []*structs.NestedLicense
+
+	// Problem that appeared while scanning, if any. It is reported for
+	// every dependency that uses this result.
+	failureCode    diagnostics.Code
+	failureMessage string
+
+	// NOTICE file data. noticeError is set if file was found but
+	// cannot be read.
+	notice      string
+	noticeError error
+	noticeFile  string
+}
+
+// Default minimum confidence of license matched in separate file for it
+// to be a part of license expression.
+const defaultThreshold = 0.8
+
+// Creates candidates list from license detector matches sorted by
+// confidence, most confident first.
+func newCandidates(licenses map[string]api.Match) []*structs.LicenseCandidate {
+	candidates := make([]*structs.LicenseCandidate, 0, len(licenses))
+
+	for name, match := range licenses {
+		candidate := &structs.LicenseCandidate{Name: name, Confidence: match.Confidence}
+
+		// Most confidently matched file, first by name on ties.
+		var fileConfidence float32
+
+		for fileName, confidence := range match.Files {
+			if confidence > fileConfidence || (confidence == fileConfidence && fileName < candidate.File) {
+				candidate.File = fileName
+				fileConfidence = confidence
+			}
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Confidence == candidates[j].Confidence {
+			return candidates[i].Name < candidates[j].Name
+		}
+
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	return candidates
+}
+
+// Selects license expression and concluded license from candidates.
+// License detector often matches several similar licenses (e.g. MIT
+// and MIT-0) with same file, so only most confident license is taken
+// from every file. Licenses from different files which confidence
+// isn't lower than threshold all apply to dependency (e.g. license of
+// bundled third-party code), unless dependency is dual licensed: has
+// known dual licensing layout (e.g. LICENSE-MIT and LICENSE-APACHE)
+// or explicitly says so in license or README files.
+func selectLicense(result *licenseScanResult, localPath string) {
+	if len(result.candidates) == 0 {
+		return
+	}
+
+	threshold := float32(configuration.Cfg.Licenses.Threshold)
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+
+	matched := make([]*structs.LicenseCandidate, 0)
+	seenFiles := make(map[string]bool)
+
+	// Candidates are sorted by confidence, so first candidate for file
+	// is the most confident one.
+	for _, candidate := range result.candidates {
+		if seenFiles[candidate.File] {
+			continue
+		}
+
+		seenFiles[candidate.File] = true
+
+		if candidate.Confidence >= threshold {
+			matched = append(matched, candidate)
+		}
+	}
+
+	if len(matched) == 0 {
+		matched = append(matched, result.candidates[0])
+	}
+
+	names := make([]string, 0, len(matched))
+	for _, candidate := range matched {
+		names = append(names, candidate.Name)
+	}
+
+	result.expression = spdx.And(names...)
+	if len(matched) > 1 && isDualLicensed(localPath, matched) {
+		result.expression = spdx.Or(names...)
+	}
+
+	// Most confident license is concluded unless dependency is
+	// licensed under choice of licenses and preferred one is
+	// available.
+	concluded := matched[0]
+
+	if expression, err := spdx.Parse(result.expression); err == nil && len(expression.Alternatives()) > 1 {
+	preferred:
+		for _, prefer := range configuration.Cfg.Licenses.Prefer {
+			for _, candidate := range matched {
+				if candidate.Name == prefer {
+					concluded = candidate
+					break preferred
+				}
+			}
+		}
+	}
+
+	result.name = concluded.Name
+	result.file = concluded.File
+	result.confidence = concluded.Confidence
+
+	// License detector falls back to README files only if no license
+	// file was found.
+	result.method = structs.DetectionMethodLicenseFile
+	if strings.HasPrefix(strings.ToLower(filepath.Base(result.file)), "readme") {
+		result.method = structs.DetectionMethodReadme
+	}
+}
+
+// Phrases (lowercased, with whitespaces collapsed) that tell about
+// licensing under choice of licenses. "At your option" alone isn't
+// enough as GPL texts use it for choosing later license versions.
+var dualLicensingPhrases = []string{
+	"dual licensed", "dual-licensed", "dually licensed", "dual license",
+	"licensed under either", "your choice of",
+}
+
+// Returns true if dependency which matched licenses are passed is
+// licensed under choice of them: every license is in separate file
+// named after it (e.g. LICENSE-MIT and LICENSE-APACHE), or license or
+// README files explicitly say so.
+func isDualLicensed(localPath string, matched []*structs.LicenseCandidate) bool {
+	layout := true
+
+	for _, candidate := range matched {
+		if !isLicenseNamedFile(candidate.File) {
+			layout = false
+			break
+		}
+	}
+
+	if layout {
+		return true
+	}
+
+	files := make([]string, 0, len(matched))
+	for _, candidate := range matched {
+		files = append(files, candidate.File)
+	}
+
+	if entries, err := ioutil.ReadDir(localPath); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasPrefix(strings.ToLower(entry.Name()), "readme") {
+				files = append(files, entry.Name())
+			}
+		}
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(filepath.Join(localPath, file))
+		if err != nil {
+			continue
+		}
+
+		text := strings.Join(strings.Fields(strings.ToLower(string(data))), " ")
+
+		for _, phrase := range dualLicensingPhrases {
+			if strings.Contains(text, phrase) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns true if passed file is a license file named after license,
+// e.g. "LICENSE-MIT", "LICENSE.APACHE" or "COPYING-GPL.txt", as used for
+// dual licensing.
+func isLicenseNamedFile(file string) bool {
+	name := strings.ToLower(filepath.Base(file))
+	if !isLicenseFile(name) {
+		return false
+	}
+
+	// Text file extensions aren't license names.
+	switch ext := filepath.Ext(name); ext {
+	case ".txt", ".md", ".markdown", ".rst":
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	for _, prefix := range licenseFilePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			suffix := strings.TrimPrefix(name, prefix)
+			return len(suffix) > 1 && (suffix[0] == '-' || suffix[0] == '.' || suffix[0] == '_')
+		}
+	}
+
+	return false
+}
+
+// Scans passed directory for license and copyrights.
+func scanLicense(localPath string) *licenseScanResult {
+	result := &licenseScanResult{}
+
+	depDir, err := filer.FromDirectory(localPath)
+	if err != nil {
+		logger.Error("Failed to prepare directory path for dependency license scan:", err.Error())
+
+		result.failureCode = diagnostics.CodeLicenseScanFailed
+		result.failureMessage = err.Error()
+
+		return result
+	}
+
+	licenses, err1 := licensedb.Detect(depDir)
+	if err1 != nil {
+		logger.Warn("Failed to detect license for", localPath+":", err1.Error())
+
+		result.failureCode = diagnostics.CodeLicenseNotFound
+		result.failureMessage = err1.Error()
+
+		return result
+	}
+
+	logger.Tracef("Got licenses result for '%s': %+v", localPath, licenses)
+
+	result.candidates = newCandidates(licenses)
+	selectLicense(result, localPath)
+
+	if result.name == "" {
+		result.failureCode = diagnostics.CodeLicenseNotFound
+		result.failureMessage = "no license matched with non-zero confidence"
+
+		return result
+	}
+
+	// As we should have dependency locally available we should try
+	// to parse license and similar files to get copyrights.
+	collector := copyrights.NewCollector()
+
+	err2 := collector.AddDirectory(localPath, result.file, configuration.Cfg.Copyrights.ScanSources)
+	if err2 != nil {
+		logger.Error("Failed to read files for copyrights:", err2.Error())
+
+		result.failureCode = diagnostics.CodeLicenseFileReadFailed
+		result.failureMessage = err2.Error()
+	}
+
+	result.copyrights = collector.Copyrights()
+
+	return result
+}
@@ -0,0 +1,147 @@
+package projecter
+
+import (
+	// stdlib
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// Creates directory with passed files.
+func createFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "glp-dep-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestIsLicenseNamedFile(t *testing.T) {
+	tests := []struct {
+		file  string
+		named bool
+	}{
+		{"LICENSE-MIT", true},
+		{"LICENSE-APACHE", true},
+		{"LICENSE.BSD", true},
+		{"LICENSE_MIT.txt", true},
+		{"COPYING-GPL.md", true},
+		{"LICENSE", false},
+		{"LICENSE.txt", false},
+		{"LICENSE.md", false},
+		{"COPYING", false},
+		{"LICENSE-", false},
+		{"license.go", false},
+		{"README.md", false},
+	}
+
+	for _, test := range tests {
+		if named := isLicenseNamedFile(test.file); named != test.named {
+			t.Errorf("isLicenseNamedFile(%q) = %v, want %v", test.file, named, test.named)
+		}
+	}
+}
+
+func TestSelectLicense(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		files      map[string]string
+		candidates []*structs.LicenseCandidate
+		expression string
+		license    string
+	}{
+		{
+			name:       "single license",
+			files:      map[string]string{"LICENSE": "MIT"},
+			candidates: []*structs.LicenseCandidate{{Name: "MIT", File: "LICENSE", Confidence: 0.98}, {Name: "MIT-0", File: "LICENSE", Confidence: 0.9}},
+			expression: "MIT",
+			license:    "MIT",
+		},
+		{
+			name:       "bundled license",
+			files:      map[string]string{"LICENSE": "Apache", "COPYING": "GPL"},
+			candidates: []*structs.LicenseCandidate{{Name: "Apache-2.0", File: "LICENSE", Confidence: 0.99}, {Name: "GPL-3.0", File: "COPYING", Confidence: 0.95}},
+			expression: "Apache-2.0 AND GPL-3.0",
+			license:    "Apache-2.0",
+		},
+		{
+			name:       "bundled license with preferred license",
+			config:     "licenses:\n  prefer: [GPL-3.0]\n",
+			files:      map[string]string{"LICENSE": "Apache", "COPYING": "GPL"},
+			candidates: []*structs.LicenseCandidate{{Name: "Apache-2.0", File: "LICENSE", Confidence: 0.99}, {Name: "GPL-3.0", File: "COPYING", Confidence: 0.95}},
+			expression: "Apache-2.0 AND GPL-3.0",
+			license:    "Apache-2.0",
+		},
+		{
+			name:       "dual licensing layout",
+			config:     "licenses:\n  prefer: [MIT]\n",
+			files:      map[string]string{"LICENSE-APACHE": "Apache", "LICENSE-MIT": "MIT"},
+			candidates: []*structs.LicenseCandidate{{Name: "Apache-2.0", File: "LICENSE-APACHE", Confidence: 0.99}, {Name: "MIT", File: "LICENSE-MIT", Confidence: 0.95}},
+			expression: "Apache-2.0 OR MIT",
+			license:    "MIT",
+		},
+		{
+			name:       "dual licensing in README",
+			files:      map[string]string{"LICENSE": "Apache", "COPYING": "MIT", "README.md": "Licensed under either of\nApache License or MIT license."},
+			candidates: []*structs.LicenseCandidate{{Name: "Apache-2.0", File: "LICENSE", Confidence: 0.99}, {Name: "MIT", File: "COPYING", Confidence: 0.95}},
+			expression: "Apache-2.0 OR MIT",
+			license:    "Apache-2.0",
+		},
+		{
+			name:       "later license version isn't dual licensing",
+			files:      map[string]string{"LICENSE": "Apache", "COPYING": "either version 3 of the License, or (at your option) any later version"},
+			candidates: []*structs.LicenseCandidate{{Name: "Apache-2.0", File: "LICENSE", Confidence: 0.99}, {Name: "GPL-3.0", File: "COPYING", Confidence: 0.95}},
+			expression: "Apache-2.0 AND GPL-3.0",
+			license:    "Apache-2.0",
+		},
+		{
+			name:       "low confidence license",
+			files:      map[string]string{"LICENSE": "Apache", "COPYING": "GPL"},
+			candidates: []*structs.LicenseCandidate{{Name: "Apache-2.0", File: "LICENSE", Confidence: 0.99}, {Name: "GPL-3.0", File: "COPYING", Confidence: 0.5}},
+			expression: "Apache-2.0",
+			license:    "Apache-2.0",
+		},
+		{
+			name:       "no confident license",
+			files:      map[string]string{"README": "MIT"},
+			candidates: []*structs.LicenseCandidate{{Name: "MIT", File: "README", Confidence: 0.6}},
+			expression: "MIT",
+			license:    "MIT",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration.InitializeForTest(t, test.config)
+
+			dir := createFiles(t, test.files)
+			defer os.RemoveAll(dir)
+
+			result := &licenseScanResult{candidates: test.candidates}
+			selectLicense(result, dir)
+
+			if result.expression != test.expression {
+				t.Errorf("expression = %q, want %q", result.expression, test.expression)
+			}
+
+			if result.name != test.license {
+				t.Errorf("license = %q, want %q", result.name, test.license)
+			}
+		})
+	}
+}
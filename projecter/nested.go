@@ -0,0 +1,248 @@
+package projecter
+
+import (
+	// stdlib
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/golist"
+	"go.dev.pztrn.name/glp/logger"
+	"go.dev.pztrn.name/glp/structs"
+)
+
+// File name prefixes (lowercased) of files that are considered to be
+// license files.
+var licenseFilePrefixes = []string{"license", "licence", "copying", "unlicense"}
+
+// Returns true if passed file name looks like license file name, e.g.
+// "LICENSE", "LICENSE-MIT", "LICENSE.BSD" or "COPYING.txt". Source files
+// like "license.go" aren't license files.
+func isLicenseFile(name string) bool {
+	name = strings.ToLower(name)
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for _, prefix := range licenseFilePrefixes {
+		if base == prefix && ext != ".go" {
+			return true
+		}
+
+		if strings.HasPrefix(base, prefix) {
+			switch ext {
+			case "", ".txt", ".md", ".markdown", ".rst":
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns true if passed directory should not be scanned for nested
+// licenses. Such directories are ignored by Go tooling and never
+// compiled into binaries.
+func skipNestedDir(name string) bool {
+	return name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// Walks dependency's directory tree and detects licenses in
+// subdirectories containing license files. Subdirectories with go.mod
+// are separate modules and are skipped.
+func scanNestedLicenses(localPath string) []*structs.NestedLicense {
+	dirs := make([]string, 0)
+	seenDirs := make(map[string]bool)
+
+	_ = filepath.Walk(localPath, func(path string, info os.FileInfo, err error) error {
+		// Unreadable dependency's directory is reported by license
+		// scan, other unreadable entries are just skipped.
+		if err != nil {
+			logger.Debug("Skipping", path, "while looking for nested licenses:", err.Error())
+			return nil
+		}
+
+		if info.IsDir() {
+			if path == localPath {
+				return nil
+			}
+
+			if skipNestedDir(info.Name()) {
+				return filepath.SkipDir
+			}
+
+			if _, err1 := os.Stat(filepath.Join(path, "go.mod")); err1 == nil {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		if dir == localPath || seenDirs[dir] || !isLicenseFile(info.Name()) {
+			return nil
+		}
+
+		seenDirs[dir] = true
+		dirs = append(dirs, dir)
+
+		return nil
+	})
+
+	nested := make([]*structs.NestedLicense, 0, len(dirs))
+
+	for _, dir := range dirs {
+		relPath, _ := filepath.Rel(localPath, dir)
+		relPath = filepath.ToSlash(relPath)
+
+		result := scanLicense(dir)
+		if result.name == "" {
+			logger.Debug("No license detected in", dir+":", result.failureMessage)
+			continue
+		}
+
+		logger.Debugf("Got nested license for '%s' in '%s': %s", localPath, relPath, result.expression)
+
+		nested = append(nested, &structs.NestedLicense{
+			Confidence: result.confidence,
+			Copyrights: result.copyrights,
+			Expression: result.expression,
+			File:       relPath + "/" + filepath.ToSlash(result.file),
+			Name:       result.name,
+			Path:       relPath,
+		})
+	}
+
+	return nested
+}
+
+// Copies nested licenses as their usage differs between projects while
+// scan result is shared.
+func copyNestedLicenses(nested []*structs.NestedLicense) []*structs.NestedLicense {
+	if len(nested) == 0 {
+		return nil
+	}
+
+	copied := make([]*structs.NestedLicense, 0, len(nested))
+
+	for _, license := range nested {
+		c := *license
+		copied = append(copied, &c)
+	}
+
+	return copied
+}
+
+// Merges nested licenses usage for aggregated dependency: nested
+// license is linked if it is linked in any project using same
+// dependency's directory.
+func mergeNestedLicenses(base *structs.Dependency, group []*structs.Dependency) []*structs.NestedLicense {
+	merged := copyNestedLicenses(base.License.Nested)
+
+	for _, license := range merged {
+		usages := make(map[string]bool)
+
+		for _, dep := range group {
+			if dep.LocalPath != base.LocalPath {
+				continue
+			}
+
+			for _, nested := range dep.License.Nested {
+				if nested.Path == license.Path {
+					usages[nested.Usage] = true
+				}
+			}
+		}
+
+		switch {
+		case usages[structs.UsageLinked]:
+			license.Usage = structs.UsageLinked
+		case usages[""]:
+			license.Usage = ""
+		default:
+			license.Usage = structs.UsageNotLinked
+		}
+	}
+
+	return merged
+}
+
+// Determines if packages covered by nested licenses are compiled into
+// project's binaries and reports nested licenses that differ from
+// dependency's license.
+func (p *Project) checkNestedLicenses() {
+	depsWithNested := make([]*structs.Dependency, 0)
+
+	for _, dep := range p.deps {
+		if len(dep.License.Nested) > 0 {
+			depsWithNested = append(depsWithNested, dep)
+		}
+	}
+
+	if len(depsWithNested) == 0 {
+		return
+	}
+
+	// Usage can be determined only for Go projects sources.
+	if info, err := os.Stat(p.packagePath); err == nil && info.IsDir() && p.parserName == "golang" {
+		p.detectNestedUsage(depsWithNested)
+	}
+
+	for _, dep := range depsWithNested {
+		for _, nested := range dep.License.Nested {
+			if nested.Usage == structs.UsageNotLinked || nested.Expression == dep.License.Expression {
+				continue
+			}
+
+			usage := nested.Usage
+			if usage == "" {
+				usage = "usage unknown"
+			}
+
+			diagnostics.DependencyWarning(dep, diagnostics.CodeNestedLicenseFound,
+				"subdirectory "+nested.Path+" is licensed under "+nested.Expression+" ("+usage+")")
+		}
+	}
+}
+
+// Marks nested licenses as linked if any package compiled into
+// project's binaries is placed in covered subdirectory. Package is
+// covered by the deepest subdirectory with license.
+func (p *Project) detectNestedUsage(deps []*structs.Dependency) {
+	packages, err := golist.Deps(p.packagePath)
+	if err != nil {
+		logger.Warn("Failed to list packages compiled into", p.packagePath+":", err.Error())
+		diagnostics.Warning(diagnostics.CodePackagesListFailed, p.packagePath, p.packagePath, err.Error())
+
+		return
+	}
+
+	for _, dep := range deps {
+		// Deepest subdirectories first.
+		nested := make([]*structs.NestedLicense, len(dep.License.Nested))
+		copy(nested, dep.License.Nested)
+
+		sort.SliceStable(nested, func(i, j int) bool { return len(nested[i].Path) > len(nested[j].Path) })
+
+		for _, license := range nested {
+			license.Usage = structs.UsageNotLinked
+		}
+
+		for _, pkg := range packages {
+			if pkg.Standard || pkg.Dir == "" {
+				continue
+			}
+
+			for _, license := range nested {
+				dir := filepath.Join(dep.LocalPath, filepath.FromSlash(license.Path))
+
+				if pkg.Dir == dir || strings.HasPrefix(pkg.Dir, dir+string(filepath.Separator)) {
+					license.Usage = structs.UsageLinked
+					break
+				}
+			}
+		}
+	}
+}
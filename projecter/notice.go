@@ -0,0 +1,77 @@
+package projecter
+
+import (
+	// stdlib
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	// local
+	"go.dev.pztrn.name/glp/spdx"
+)
+
+// Files bigger than that aren't considered to be NOTICE files.
+const maxNoticeSize = 1024 * 1024
+
+// Looks for NOTICE file (e.g. "NOTICE", "NOTICE.txt" or "NOTICE.md") in
+// dependency's root directory and reads it. Returns empty file name if
+// there is no NOTICE file. Unreadable directory is reported by license
+// scan, so error is returned only if NOTICE file cannot be read.
+func readNotice(localPath string) (string, string, error) {
+	files, err := ioutil.ReadDir(localPath)
+	if err != nil {
+		return "", "", nil
+	}
+
+	candidates := make([]string, 0)
+
+	for _, file := range files {
+		name := strings.ToLower(file.Name())
+		if file.IsDir() || file.Size() > maxNoticeSize || strings.TrimSuffix(name, filepath.Ext(name)) != "notice" {
+			continue
+		}
+
+		candidates = append(candidates, file.Name())
+	}
+
+	if len(candidates) == 0 {
+		return "", "", nil
+	}
+
+	// "NOTICE" goes before "NOTICE.md" and "NOTICE.txt".
+	sort.Strings(candidates)
+
+	data, err1 := ioutil.ReadFile(filepath.Join(localPath, candidates[0]))
+	if err1 != nil {
+		return candidates[0], "", err1
+	}
+
+	text := strings.TrimRight(strings.Trim(strings.Replace(string(data), "\r\n", "\n", -1), "\n"), " \t\n")
+
+	return candidates[0], text, nil
+}
+
+// Returns true if any of detected licenses requires NOTICE file
+// redistribution. Every operand of expression and every candidate are
+// checked, as concluded license might be another operand or might be
+// replaced with less confident match.
+func requiresNotice(result *licenseScanResult) bool {
+	names := []string{result.name}
+
+	if expression, err := spdx.Parse(result.expression); err == nil {
+		names = append(names, expression.Licenses()...)
+	}
+
+	for _, candidate := range result.candidates {
+		names = append(names, candidate.Name)
+	}
+
+	for _, name := range names {
+		if strings.HasPrefix(name, "Apache-") {
+			return true
+		}
+	}
+
+	return false
+}
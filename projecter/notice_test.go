@@ -0,0 +1,32 @@
+package projecter
+
+import (
+	// stdlib
+	"testing"
+
+	// local
+	"go.dev.pztrn.name/glp/structs"
+)
+
+func TestRequiresNotice(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *licenseScanResult
+		want   bool
+	}{
+		{"concluded license", &licenseScanResult{name: "Apache-2.0", expression: "Apache-2.0"}, true},
+		{"expression operand", &licenseScanResult{name: "MIT", expression: "MIT AND Apache-2.0"}, true},
+		{"candidate", &licenseScanResult{name: "MIT", expression: "MIT", candidates: []*structs.LicenseCandidate{
+			{Name: "MIT", Confidence: 0.9},
+			{Name: "Apache-2.0", Confidence: 0.8},
+		}}, true},
+		{"no apache", &licenseScanResult{name: "MIT", expression: "MIT OR BSD-3-Clause"}, false},
+		{"nothing detected", &licenseScanResult{}, false},
+	}
+
+	for _, test := range tests {
+		if got := requiresNotice(test.result); got != test.want {
+			t.Errorf("%s: requiresNotice() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
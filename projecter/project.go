@@ -2,20 +2,19 @@ package projecter
 
 import (
 	// stdlib
-	"bufio"
-	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
 	// local
 	"go.dev.pztrn.name/glp/configuration"
+	"go.dev.pztrn.name/glp/diagnostics"
+	"go.dev.pztrn.name/glp/logger"
 	"go.dev.pztrn.name/glp/parsers"
 	"go.dev.pztrn.name/glp/structs"
-
-	// other
-	"gopkg.in/src-d/go-license-detector.v3/licensedb"
-	"gopkg.in/src-d/go-license-detector.v3/licensedb/filer"
+	"go.dev.pztrn.name/glp/workers"
 )
 
 // Project represents single project (or package) that was passed via
@@ -50,7 +49,7 @@ func (p *Project) initialize(packagePath string) {
 	if strings.Contains(p.packagePath, "~") {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			log.Fatalln("Failed to get user's home directory:", err.Error())
+			logger.Fatal("Failed to get user's home directory:", err.Error())
 		}
 
 		p.packagePath = strings.Replace(p.packagePath, "~", homeDir, -1)
@@ -60,33 +59,8 @@ func (p *Project) initialize(packagePath string) {
 	var err error
 	p.packagePath, err = filepath.Abs(p.packagePath)
 	if err != nil {
-		log.Fatalln("Failed to get absolute path for package '"+p.packagePath+":", err.Error())
-	}
-}
-
-// Parses license file for copyrights.
-func (p *Project) parseLicenseForCopyrights(licencePath string) []string {
-	f, err := os.Open(licencePath)
-	if err != nil {
-		log.Println("Failed to open license file for reading:", err.Error())
-		return nil
-	}
-
-	var copyrights []string
-
-	// Read file data line by line.
-	gosum := bufio.NewScanner(f)
-	gosum.Split(bufio.ScanLines)
-
-	for gosum.Scan() {
-		line := gosum.Text()
-
-		if strings.HasPrefix(strings.ToLower(line), "copyright ") && !strings.Contains(strings.ToLower(line), "notice") {
-			copyrights = append(copyrights, line)
-		}
+		logger.Fatal("Failed to get absolute path for package '"+p.packagePath+":", err.Error())
 	}
-
-	return copyrights
 }
 
 // Starts project parsing.
@@ -95,80 +69,118 @@ func (p *Project) process() {
 	p.parserName, p.flavor = parsers.Detect(p.packagePath)
 
 	if p.parserName == "unknown" {
-		log.Println("Project", p.packagePath, "cannot be parsed with glp")
+		logger.Warn("Project", p.packagePath, "cannot be parsed with glp")
+		diagnostics.Error(diagnostics.CodeProjectNotSupported, p.packagePath, p.packagePath, "no parser can handle this project")
+
 		return
 	}
 
 	// Lets try to get dependencies, their versions and URLs.
 	deps, err := parsers.GetDependencies(p.parserName, p.flavor, p.packagePath)
 	if err != nil {
-		log.Fatalln("Failed to get dependencies:", err.Error())
+		logger.Fatal("Failed to get dependencies:", err.Error())
 	}
 
 	p.deps = deps
 
-	// Get licensing information for every dependency.
+	// Get licensing information for every dependency. Scanning itself
+	// is limited by workers pool and deduplicated across projects as
+	// same dependency version is placed in same directory.
+	var wg sync.WaitGroup
+
 	for _, dep := range p.deps {
-		// Prepare dependency's things. For now - only check if
-		// file/directory templates defined and, if not, generate
-		// them.
-		dep.VCS.FormatSourcePaths()
+		wg.Add(1)
 
-		depDir, err := filer.FromDirectory(dep.LocalPath)
-		if err != nil {
-			log.Println("Failed to prepare directory path for dependency license scan:", err.Error())
-			continue
-		}
+		go func(dep *structs.Dependency) {
+			p.processDependency(dep)
+			wg.Done()
+		}(dep)
+	}
+
+	wg.Wait()
 
-		licenses, err1 := licensedb.Detect(depDir)
-		if err1 != nil {
-			log.Println("Failed to detect license for", dep.Name+":", err1.Error())
+	if configuration.Cfg.Licenses.Nested {
+		p.checkNestedLicenses()
+	}
+}
 
-			dep.License.Name = "Unknown"
+// Gets licensing information for dependency.
+func (p *Project) processDependency(dep *structs.Dependency) {
+	// Prepare dependency's things. For now - only check if
+	// file/directory templates defined and, if not, generate
+	// them.
+	dep.VCS.FormatSourcePaths()
+
+	// Dependency's sources aren't available, parser is responsible for
+	// reporting why.
+	if dep.LocalPath == "" {
+		dep.License.Name = "Unknown"
+		return
+	}
 
-			continue
-		}
+	result := workers.Do("license:"+dep.LocalPath, func() interface{} {
+		result := scanLicense(dep.LocalPath)
+		result.noticeFile, result.notice, result.noticeError = readNotice(dep.LocalPath)
 
-		if configuration.Cfg.Log.Debug {
-			log.Printf("Got licenses result for '%s': %+v\n", dep.Name, licenses)
+		if configuration.Cfg.Licenses.Nested {
+			result.nested = scanNestedLicenses(dep.LocalPath)
 		}
 
-		// Get highest ranked license.
-		var (
-			licenseFile string
-			licenseName string
-			licenseRank float32
-		)
-
-		for name, result := range licenses {
-			if licenseRank < result.Confidence {
-				licenseName = name
-				licenseRank = result.Confidence
-
-				for fileName, confidence := range result.Files {
-					if confidence == licenseRank {
-						licenseFile = fileName
-						break
-					}
-				}
-			}
-		}
+		return result
+	}).(*licenseScanResult)
+
+	if result.failureCode != "" {
+		diagnostics.DependencyError(dep, result.failureCode, result.failureMessage)
+	}
+
+	dep.License.NoticeFile = result.noticeFile
+	dep.License.Notice = result.notice
+	dep.License.Nested = copyNestedLicenses(result.nested)
 
-		if licenseName == "" {
-			dep.License.Name = "Unknown"
-			continue
+	// Apache-2.0 requires NOTICE file redistribution, so it is an
+	// error if it cannot be read.
+	if result.noticeError != nil {
+		logger.Warn("Failed to read NOTICE file for", dep.Name+":", result.noticeError.Error())
+
+		if requiresNotice(result) {
+			diagnostics.DependencyError(dep, diagnostics.CodeNoticeFileReadFailed, "NOTICE file cannot be read: "+result.noticeError.Error())
+		} else {
+			diagnostics.DependencyWarning(dep, diagnostics.CodeNoticeFileReadFailed, "NOTICE file cannot be read: "+result.noticeError.Error())
 		}
+	}
+
+	if result.name == "" {
+		dep.License.Name = "Unknown"
+		return
+	}
 
-		log.Printf("Got license for '%s': %s", dep.Name, licenseName)
+	logger.WithFields(logger.Fields{"dependency": dep.Name, "version": dep.Version, "license": result.name}).Debugf("Got license for '%s': %s", dep.Name, result.name)
 
-		dep.License.Name = licenseName
+	dep.License.Candidates = result.candidates
+	dep.License.Confidence = result.confidence
+	dep.License.DetectionMethod = result.method
+	dep.License.Expression = result.expression
+	dep.License.Name = result.name
+	dep.License.File = result.file
 
-		// Generate license URL.
-		urlFormatter := strings.NewReplacer("{dir}", "", "{/dir}", "", "{file}", licenseFile, "{/file}", licenseFile, "#L{line}", "")
-		dep.License.URL = urlFormatter.Replace(dep.VCS.SourceURLFileTemplate)
+	// Uncertain results are marked for manual review. Detected license
+	// is still available in expression and candidates.
+	minConfidence := configuration.Cfg.Licenses.MinConfidence
+	if minConfidence > 0 && float64(result.confidence) < minConfidence {
+		dep.License.Name = structs.LicenseNeedsReview
 
-		// As we should have dependency locally available we should try
-		// to parse license file to get copyrights.
-		dep.License.Copyrights = p.parseLicenseForCopyrights(filepath.Join(dep.LocalPath, licenseFile))
+		diagnostics.DependencyWarning(dep, diagnostics.CodeLicenseNeedsReview, "license "+result.name+" was detected with confidence "+
+			strconv.FormatFloat(float64(result.confidence), 'f', 2, 64)+" which is lower than minimum "+strconv.FormatFloat(minConfidence, 'f', 2, 64))
 	}
+
+	// Generate license URL.
+	var slashDir string
+	if dep.VCS.Dir != "" {
+		slashDir = "/" + dep.VCS.Dir
+	}
+
+	urlFormatter := strings.NewReplacer("{dir}", dep.VCS.Dir, "{/dir}", slashDir, "{file}", result.file, "{/file}", result.file, "#L{line}", "")
+	dep.License.URL = urlFormatter.Replace(dep.VCS.SourceURLFileTemplate)
+
+	dep.License.Copyrights = result.copyrights
 }
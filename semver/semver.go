@@ -0,0 +1,126 @@
+package semver
+
+import (
+	// stdlib
+	"strconv"
+	"strings"
+)
+
+// This structure represents parsed semantic version.
+type version struct {
+	major      int
+	minor      int
+	patch      int
+	prerelease []string
+}
+
+// Parses version string. Leading "v" and build metadata (including
+// "+incompatible") are ignored, missing minor and patch are assumed
+// to be zero.
+func parse(v string) (*version, bool) {
+	v = strings.TrimPrefix(v, "v")
+
+	if idx := strings.Index(v, "+"); idx != -1 {
+		v = v[:idx]
+	}
+
+	parsed := &version{}
+
+	if idx := strings.Index(v, "-"); idx != -1 {
+		parsed.prerelease = strings.Split(v[idx+1:], ".")
+		v = v[:idx]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return nil, false
+	}
+
+	numbers := []*int{&parsed.major, &parsed.minor, &parsed.patch}
+
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return nil, false
+		}
+
+		*numbers[i] = number
+	}
+
+	return parsed, true
+}
+
+// Compare compares two versions and returns -1 if a is lower than b,
+// 0 if they're equal and 1 if a is greater than b. If one of versions
+// isn't a semantic version (e.g. it is a commit hash) - versions are
+// compared as strings.
+func Compare(a string, b string) int {
+	va, okA := parse(a)
+	vb, okB := parse(b)
+
+	if !okA || !okB {
+		return strings.Compare(a, b)
+	}
+
+	if c := compareInts(va.major, vb.major); c != 0 {
+		return c
+	}
+
+	if c := compareInts(va.minor, vb.minor); c != 0 {
+		return c
+	}
+
+	if c := compareInts(va.patch, vb.patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(va.prerelease, vb.prerelease)
+}
+
+// Compares integers.
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// Compares prerelease identifiers as described in semver specification.
+func comparePrerelease(a []string, b []string) int {
+	// Version without prerelease have higher precedence.
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		numA, errA := strconv.Atoi(a[i])
+		numB, errB := strconv.Atoi(b[i])
+
+		switch {
+		case errA == nil && errB == nil:
+			if c := compareInts(numA, numB); c != 0 {
+				return c
+			}
+		case errA == nil:
+			// Numeric identifiers have lower precedence.
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInts(len(a), len(b))
+}
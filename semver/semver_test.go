@@ -0,0 +1,40 @@
+package semver
+
+import (
+	// stdlib
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a      string
+		b      string
+		result int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "1.0.0", 0},
+		{"v1.2", "v1.2.0", 0},
+		{"v1.0.0", "v1.0.1", -1},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v2.0.0", "v10.0.0", -1},
+		{"v2.0.0+incompatible", "v2.0.0", 0},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-alpha", "v1.0.0-alpha.1", -1},
+		{"v1.0.0-alpha.1", "v1.0.0-alpha.beta", -1},
+		{"v1.0.0-beta.2", "v1.0.0-beta.11", -1},
+		{"v1.0.0-rc.1", "v1.0.0-beta.11", 1},
+		{"v0.0.0-20191112221441-2f1e5c8b3a2b", "v0.0.0-20170918181015-86672fcb3f95", 1},
+		{"86672fcb3f95", "2f1e5c8b3a2b", 1},
+		{"v1.a.0", "v1.0.0", 1},
+	}
+
+	for _, test := range tests {
+		if result := Compare(test.a, test.b); result != test.result {
+			t.Errorf("Compare(%q, %q) = %d, want %d", test.a, test.b, result, test.result)
+		}
+
+		if result := Compare(test.b, test.a); result != -test.result {
+			t.Errorf("Compare(%q, %q) = %d, want %d", test.b, test.a, result, -test.result)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package spdx
+
+import (
+	// stdlib
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// Operators supported in license expressions.
+	OperatorAnd = "AND"
+	OperatorOr  = "OR"
+)
+
+// Expression is a parsed SPDX license expression. It is either a single
+// license (with optional exception) or an operator applied to two
+// expressions.
+type Expression struct {
+	// License is a license identifier. Empty for operator nodes.
+	License string
+	// Exception is a license exception identifier, e.g.
+	// "Classpath-exception-2.0" in "GPL-2.0 WITH Classpath-exception-2.0".
+	Exception string
+	// Operator is an "AND" or "OR" operator. Empty for license nodes.
+	Operator string
+	// Left and Right are operator's operands.
+	Left  *Expression
+	Right *Expression
+}
+
+// Parse parses SPDX license expression. "AND" has higher precedence
+// than "OR", parentheses can be used for grouping. Operators are case
+// insensitive.
+func Parse(expression string) (*Expression, error) {
+	p := &parser{tokens: tokenize(expression)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("license expression is empty")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, errors.New("unexpected '" + p.tokens[p.pos] + "' in license expression '" + expression + "'")
+	}
+
+	return e, nil
+}
+
+// And creates expression that requires complying with all passed
+// licenses. Licenses are sorted to make expression stable.
+func And(licenses ...string) string {
+	return join(OperatorAnd, licenses)
+}
+
+// Or creates expression that allows choosing one of passed licenses.
+// Licenses are sorted to make expression stable.
+func Or(licenses ...string) string {
+	return join(OperatorOr, licenses)
+}
+
+// Joins sorted licenses with passed operator.
+func join(operator string, licenses []string) string {
+	sorted := make([]string, len(licenses))
+	copy(sorted, licenses)
+	sort.Strings(sorted)
+
+	return strings.Join(sorted, " "+operator+" ")
+}
+
+// Licenses returns all licenses identifiers used in expression in order
+// of appearance.
+func (e *Expression) Licenses() []string {
+	if e.Operator == "" {
+		return []string{e.License}
+	}
+
+	return append(e.Left.Licenses(), e.Right.Licenses()...)
+}
+
+// Alternatives returns licenses that can be chosen from if expression is
+// a choice of single licenses ("A OR B OR C"). It returns nil if
+// expression isn't such choice.
+func (e *Expression) Alternatives() []string {
+	switch {
+	case e.Operator == "":
+		return []string{e.License}
+	case e.Operator == OperatorOr:
+		left := e.Left.Alternatives()
+		right := e.Right.Alternatives()
+
+		if left == nil || right == nil {
+			return nil
+		}
+
+		return append(left, right...)
+	}
+
+	return nil
+}
+
+// Satisfied returns true if expression is satisfied when licenses for
+// which passed function returns true are acceptable: any operand of
+// "OR" and all operands of "AND" should be acceptable.
+func (e *Expression) Satisfied(acceptable func(license string) bool) bool {
+	switch e.Operator {
+	case OperatorAnd:
+		return e.Left.Satisfied(acceptable) && e.Right.Satisfied(acceptable)
+	case OperatorOr:
+		return e.Left.Satisfied(acceptable) || e.Right.Satisfied(acceptable)
+	}
+
+	return acceptable(e.License)
+}
+
+// String returns expression in canonical form.
+func (e *Expression) String() string {
+	if e.Operator == "" {
+		if e.Exception != "" {
+			return e.License + " WITH " + e.Exception
+		}
+
+		return e.License
+	}
+
+	return e.operand(e.Left) + " " + e.Operator + " " + e.operand(e.Right)
+}
+
+// Returns operand string, wrapped in parentheses if it has lower
+// precedence than this expression's operator.
+func (e *Expression) operand(operand *Expression) string {
+	if e.Operator == OperatorAnd && operand.Operator == OperatorOr {
+		return "(" + operand.String() + ")"
+	}
+
+	return operand.String()
+}
+
+// Splits expression into tokens.
+func tokenize(expression string) []string {
+	expression = strings.Replace(expression, "(", " ( ", -1)
+	expression = strings.Replace(expression, ")", " ) ", -1)
+
+	return strings.Fields(expression)
+}
+
+// Recursive descent parser for license expressions.
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+// Returns current token in upper case or empty string if all tokens was
+// consumed.
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return strings.ToUpper(p.tokens[p.pos])
+}
+
+// Parses "OR" operands.
+func (p *parser) parseOr() (*Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == OperatorOr {
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &Expression{Operator: OperatorOr, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// Parses "AND" operands.
+func (p *parser) parseAnd() (*Expression, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == OperatorAnd {
+		p.pos++
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &Expression{Operator: OperatorAnd, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// Parses license (with optional exception) or expression in
+// parentheses.
+func (p *parser) parseTerm() (*Expression, error) {
+	token := p.peek()
+
+	switch token {
+	case "":
+		return nil, errors.New("unexpected end of license expression")
+	case OperatorAnd, OperatorOr, "WITH", ")":
+		return nil, errors.New("unexpected '" + p.tokens[p.pos] + "' in license expression")
+	case "(":
+		p.pos++
+
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ")" {
+			return nil, errors.New("missing ')' in license expression")
+		}
+
+		p.pos++
+
+		return e, nil
+	}
+
+	e := &Expression{License: p.tokens[p.pos]}
+	p.pos++
+
+	if p.peek() == "WITH" {
+		p.pos++
+
+		if exception := p.peek(); exception == "" || exception == "(" || exception == ")" {
+			return nil, errors.New("missing exception after 'WITH' in license expression")
+		}
+
+		e.Exception = p.tokens[p.pos]
+		p.pos++
+	}
+
+	return e, nil
+}
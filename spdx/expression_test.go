@@ -0,0 +1,97 @@
+package spdx
+
+import (
+	// stdlib
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expression   string
+		canonical    string
+		licenses     []string
+		alternatives []string
+	}{
+		{"MIT", "MIT", []string{"MIT"}, []string{"MIT"}},
+		{"MIT OR Apache-2.0", "MIT OR Apache-2.0", []string{"MIT", "Apache-2.0"}, []string{"MIT", "Apache-2.0"}},
+		{"mit or Apache-2.0 or BSD-3-Clause", "mit OR Apache-2.0 OR BSD-3-Clause", []string{"mit", "Apache-2.0", "BSD-3-Clause"}, []string{"mit", "Apache-2.0", "BSD-3-Clause"}},
+		{"MIT AND Apache-2.0", "MIT AND Apache-2.0", []string{"MIT", "Apache-2.0"}, nil},
+		{"MIT OR Apache-2.0 AND BSD-3-Clause", "MIT OR Apache-2.0 AND BSD-3-Clause", []string{"MIT", "Apache-2.0", "BSD-3-Clause"}, nil},
+		{"(MIT OR Apache-2.0) AND BSD-3-Clause", "(MIT OR Apache-2.0) AND BSD-3-Clause", []string{"MIT", "Apache-2.0", "BSD-3-Clause"}, nil},
+		{"GPL-2.0 WITH Classpath-exception-2.0", "GPL-2.0 WITH Classpath-exception-2.0", []string{"GPL-2.0"}, []string{"GPL-2.0"}},
+		{"((MIT))", "MIT", []string{"MIT"}, []string{"MIT"}},
+	}
+
+	for _, test := range tests {
+		e, err := Parse(test.expression)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", test.expression, err)
+			continue
+		}
+
+		if e.String() != test.canonical {
+			t.Errorf("Parse(%q).String() = %q, want %q", test.expression, e.String(), test.canonical)
+		}
+
+		if !reflect.DeepEqual(e.Licenses(), test.licenses) {
+			t.Errorf("Parse(%q).Licenses() = %q, want %q", test.expression, e.Licenses(), test.licenses)
+		}
+
+		if !reflect.DeepEqual(e.Alternatives(), test.alternatives) {
+			t.Errorf("Parse(%q).Alternatives() = %q, want %q", test.expression, e.Alternatives(), test.alternatives)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expression := range []string{"", "   ", "MIT OR", "AND MIT", "(MIT", "MIT)", "MIT Apache-2.0", "GPL-2.0 WITH", "MIT OR ()"} {
+		if e, err := Parse(expression); err == nil {
+			t.Errorf("Parse(%q) = %q, want error", expression, e.String())
+		}
+	}
+}
+
+func TestSatisfied(t *testing.T) {
+	allowed := map[string]bool{"MIT": true, "Apache-2.0": true}
+	acceptable := func(license string) bool { return allowed[license] }
+
+	tests := []struct {
+		expression string
+		satisfied  bool
+	}{
+		{"MIT", true},
+		{"GPL-3.0", false},
+		{"MIT OR GPL-3.0", true},
+		{"MIT AND GPL-3.0", false},
+		{"MIT AND Apache-2.0", true},
+		{"(MIT OR GPL-3.0) AND Apache-2.0", true},
+		{"(MIT OR GPL-3.0) AND LGPL-3.0", false},
+		{"GPL-3.0 OR MIT AND Apache-2.0", true},
+	}
+
+	for _, test := range tests {
+		e, err := Parse(test.expression)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", test.expression, err)
+		}
+
+		if e.Satisfied(acceptable) != test.satisfied {
+			t.Errorf("Parse(%q).Satisfied() = %v, want %v", test.expression, !test.satisfied, test.satisfied)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if got := Or("MIT", "Apache-2.0"); got != "Apache-2.0 OR MIT" {
+		t.Errorf("Or() = %q, want %q", got, "Apache-2.0 OR MIT")
+	}
+
+	if got := And("MIT", "Apache-2.0", "BSD-3-Clause"); got != "Apache-2.0 AND BSD-3-Clause AND MIT" {
+		t.Errorf("And() = %q, want %q", got, "Apache-2.0 AND BSD-3-Clause AND MIT")
+	}
+
+	if got := And("MIT"); got != "MIT" {
+		t.Errorf("And() = %q, want %q", got, "MIT")
+	}
+}
@@ -1,21 +1,55 @@
 package structs
 
+const (
+	// VerificationOK means that dependency's content matches checksum.
+	VerificationOK = "ok"
+	// VerificationMismatch means that dependency's content doesn't
+	// match checksum, e.g. it was modified.
+	VerificationMismatch = "mismatch"
+	// VerificationFailed means that dependency's content cannot be
+	// read to compute checksum.
+	VerificationFailed = "failed"
+)
+
 // Dependency represents single dependency data.
 type Dependency struct {
+	// Checksum is a dependency's content checksum from package
+	// manager's lock file (e.g. "h1:" hash from go.sum) or executable's
+	// build information.
+	Checksum string `json:"checksum,omitempty"`
 	// License is a license name for dependency.
-	License License
+	License License `json:"license"`
 	// LocalPath is a path to dependency (if vendored or in GOPATH or
 	// in module cache).
-	LocalPath string
+	LocalPath string `json:"local_path,omitempty"`
 	// Name is a dependency name as it appears in package manager's
-	// lock file or in sources if no package manager is used.
-	Name string
-	// Parent is a path to parent package.
-	Parent string
+	// lock file or in sources if no package manager is used. For Go
+	// modules it is a canonical module path (including major version
+	// suffix).
+	Name string `json:"name"`
+	// Parent is a path to parent package. For aggregated dependencies
+	// it contains all parents delimited with comma.
+	Parent string `json:"parent,omitempty"`
+	// Parents is a list of parent packages for aggregated dependency.
+	// Empty if dependency wasn't aggregated.
+	Parents []string `json:"parents,omitempty"`
+	// RepositoryPath is a path used for dependency's repository
+	// lookups (e.g. go-import data) if it differs from name, e.g. Go
+	// module path without major version suffix.
+	RepositoryPath string `json:"repository_path,omitempty"`
 	// VCS is a VCS data obtained for dependency.
-	VCS VCSData
-	// Version is a dependency version used in project.
-	Version string
+	VCS VCSData `json:"vcs"`
+	// Verification is a result of dependency's content verification
+	// against checksum, see Verification* constants. Empty if content
+	// wasn't verified.
+	Verification string `json:"verification,omitempty"`
+	// Version is a dependency version used in project. For dependencies
+	// aggregated by module it contains all versions delimited with
+	// comma.
+	Version string `json:"version"`
+	// Versions is a list of versions for dependency aggregated by
+	// module. Empty if dependency wasn't aggregated by module.
+	Versions []string `json:"versions,omitempty"`
 	// URL is a web URL for that dependency (Github, Gitlab, etc.).
-	URL string
+	URL string `json:"url,omitempty"`
 }
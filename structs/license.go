@@ -1,7 +1,108 @@
 package structs
 
+const (
+	// LicenseNeedsReview is a license name for dependencies which
+	// license was detected with confidence lower than configured
+	// minimum.
+	LicenseNeedsReview = "NeedsReview"
+
+	// DetectionMethodLicenseFile means that license was detected by
+	// matching license file text.
+	DetectionMethodLicenseFile = "license-file"
+	// DetectionMethodReadme means that license was detected from
+	// license section of README file as no license file was found.
+	DetectionMethodReadme = "readme"
+
+	// UsageLinked means that packages covered by nested license are
+	// compiled into project's binaries.
+	UsageLinked = "linked"
+	// UsageNotLinked means that no package covered by nested license
+	// is compiled into project's binaries.
+	UsageNotLinked = "not-linked"
+)
+
+// License describes dependency's license.
 type License struct {
-	Copyrights []string
-	Name       string
-	URL        string
+	// Candidates is a list of all licenses matched while detecting
+	// dependency's license, most confident first.
+	Candidates []*LicenseCandidate `json:"candidates,omitempty"`
+	// Confidence is a concluded license match confidence, from 0 to 1.
+	Confidence float32 `json:"confidence,omitempty"`
+	// Copyrights is a list of normalized copyright statements found in
+	// license, NOTICE, AUTHORS, COPYING files and, if enabled, source
+	// files headers.
+	Copyrights []string `json:"copyrights,omitempty"`
+	// File is a path to license file relative to dependency's local
+	// path.
+	File string `json:"file,omitempty"`
+	// Notice is a NOTICE file text. Apache-2.0 requires redistributing
+	// it with dependency.
+	Notice string `json:"notice,omitempty"`
+	// NoticeFile is a path to NOTICE file relative to dependency's
+	// local path. Empty if dependency has no NOTICE file.
+	NoticeFile string `json:"notice_file,omitempty"`
+	// DetectionMethod is a way license was detected, see
+	// DetectionMethod* constants.
+	DetectionMethod string `json:"detection_method,omitempty"`
+	// Expression is a SPDX license expression, e.g. "Apache-2.0 OR MIT"
+	// for dual licensed dependency. Equals to Name for dependencies
+	// licensed under single license.
+	Expression string `json:"expression,omitempty"`
+	// Nested is a list of licenses found in dependency's
+	// subdirectories, e.g. for bundled third-party code. Filled only
+	// if nested licenses detection is enabled.
+	Nested []*NestedLicense `json:"nested,omitempty"`
+	// Name is a concluded license name (SPDX identifier). For
+	// dependencies licensed under choice of licenses it is a chosen
+	// one. It is LicenseNeedsReview if confidence is lower than
+	// configured minimum.
+	Name string `json:"name"`
+	// URL is a web URL for license file.
+	URL string `json:"url,omitempty"`
+}
+
+// LicenseCandidate is a license matched while detecting dependency's
+// license.
+type LicenseCandidate struct {
+	// Confidence is a match confidence, from 0 to 1.
+	Confidence float32 `json:"confidence"`
+	// File is a path to most confidently matched file relative to
+	// dependency's local path.
+	File string `json:"file,omitempty"`
+	// Name is a license name (SPDX identifier).
+	Name string `json:"name"`
+}
+
+// NestedLicense is a license found in dependency's subdirectory. It
+// covers packages placed in that subdirectory.
+type NestedLicense struct {
+	// Confidence is a license match confidence, from 0 to 1.
+	Confidence float32 `json:"confidence,omitempty"`
+	// Copyrights is a list of normalized copyright statements found in
+	// subdirectory's license, NOTICE, AUTHORS and COPYING files.
+	Copyrights []string `json:"copyrights,omitempty"`
+	// Expression is a SPDX license expression.
+	Expression string `json:"expression,omitempty"`
+	// File is a path to license file relative to dependency's local
+	// path.
+	File string `json:"file"`
+	// Name is a concluded license name (SPDX identifier).
+	Name string `json:"name"`
+	// Path is a path to subdirectory relative to dependency's local
+	// path.
+	Path string `json:"path"`
+	// Usage tells if covered packages are compiled into project's
+	// binaries, see Usage* constants. Empty if it is unknown.
+	Usage string `json:"usage,omitempty"`
+}
+
+// String returns nested license description, e.g.
+// "internal/third_party/foo: BSD-3-Clause (linked)".
+func (n *NestedLicense) String() string {
+	description := n.Path + ": " + n.Expression
+	if n.Usage != "" {
+		description += " (" + n.Usage + ")"
+	}
+
+	return description
 }
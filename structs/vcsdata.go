@@ -8,39 +8,122 @@ import (
 // VCSData describes structure of go-import and go-source data.
 type VCSData struct {
 	// Branch is a VCS branch used.
-	Branch string
+	Branch string `json:"branch,omitempty"`
+	// Dir is a dependency's directory within repository. Empty if
+	// dependency is placed in repository root.
+	Dir string `json:"dir,omitempty"`
 	// Revision is a VCS revision used.
-	Revision string
+	Revision string `json:"revision,omitempty"`
 	// SourceURLDirTemplate is a template for sources dirs URLs. E.g.:
 	// https://sources.dev.pztrn.name/pztrn/glp/src/branch/master{/dir}
-	SourceURLDirTemplate string
+	SourceURLDirTemplate string `json:"source_url_dir_template,omitempty"`
 	// SourceURLFileTemplate is a template for sources files URLs. E.g.:
 	// https://sources.dev.pztrn.name/pztrn/glp/src/branch/master{/dir}/{file}#L{line}
-	SourceURLFileTemplate string
+	SourceURLFileTemplate string `json:"source_url_file_template,omitempty"`
+	// Tag is a VCS tag used, without directory prefix.
+	Tag string `json:"tag,omitempty"`
 	// VCS is a VCS name (e.g. "git").
-	VCS string
+	VCS string `json:"vcs,omitempty"`
 	// VCSPath is a VCS repository path.
-	VCSPath string
+	VCSPath string `json:"vcs_path,omitempty"`
+}
+
+// Sites which sources URLs have reference placed after known marker,
+// e.g. "https://github.com/user/repo/blob/master{/dir}/{file}#L{line}"
+// or "https://go.googlesource.com/text/+/master{/dir}/{file}#{line}".
+var sourceURLRefMarkers = map[string][]string{
+	"github.com/":        {"/blob/", "/tree/"},
+	".googlesource.com/": {"/+/"},
 }
 
 // FormatSourcePaths tries to create templates which will be used for
 // paths formatting. E.g. when generating path to license file.
 // This is required because for some repositories github.com (and
 // probably gitlab.com too) might not return go-source element in
-// page's <head> tag.
+// page's <head> tag. Templates from go-source element usually point to
+// default branch, so reference in them is replaced with dependency's
+// one for known sites.
 func (vd *VCSData) FormatSourcePaths() {
-	// Do nothing if templates was filled (e.g. when parsing HTML page
-	// for repository with "?go-get=1" parameter).
-	if vd.SourceURLDirTemplate != "" && vd.SourceURLFileTemplate != "" {
+	// Sources URLs are useless without known reference.
+	ref := vd.Ref()
+	if ref == "" {
 		return
 	}
 
+	// Templates was filled (e.g. when parsing HTML page for repository
+	// with "?go-get=1" parameter).
+	if vd.SourceURLDirTemplate != "" && vd.SourceURLFileTemplate != "" {
+		dirTemplate, dirOK := replaceSourceURLRef(vd.SourceURLDirTemplate, ref)
+		fileTemplate, fileOK := replaceSourceURLRef(vd.SourceURLFileTemplate, ref)
+
+		if dirOK && fileOK {
+			vd.SourceURLDirTemplate = dirTemplate
+			vd.SourceURLFileTemplate = fileTemplate
+
+			return
+		}
+
+		// Unknown templates are kept unless they can be generated.
+		if !vd.isGithub() {
+			return
+		}
+	}
+
 	// If no URL templates was provided by github and we know that
 	// dependency is using it as VCS storage - generate proper
 	// template URLs.
-	if vd.VCS == "git" && vd.VCSPath != "" && strings.Contains(vd.VCSPath, "github.com") {
-		repoPathSplitted := strings.Split(vd.VCSPath, ".")
-		vd.SourceURLDirTemplate = strings.Join(repoPathSplitted[:len(repoPathSplitted)-1], ".") + "/blob/" + vd.Branch + "{/dir}"
-		vd.SourceURLFileTemplate = strings.Join(repoPathSplitted[:len(repoPathSplitted)-1], ".") + "/blob/" + vd.Branch + "{/dir}/{file}#L{line}"
+	if vd.isGithub() {
+		repoURL := strings.TrimSuffix(strings.TrimSuffix(vd.VCSPath, "/"), ".git")
+		vd.SourceURLDirTemplate = repoURL + "/tree/" + ref + "{/dir}"
+		vd.SourceURLFileTemplate = repoURL + "/blob/" + ref + "{/dir}/{file}#L{line}"
 	}
 }
+
+// Returns true if dependency is stored in git repository on GitHub.
+func (vd *VCSData) isGithub() bool {
+	return vd.VCS == "git" && strings.Contains(vd.VCSPath, "github.com")
+}
+
+// Replaces reference in sources URL template of known site. Returns
+// false if template isn't known.
+func replaceSourceURLRef(template string, ref string) (string, bool) {
+	for site, markers := range sourceURLRefMarkers {
+		if !strings.Contains(template, site) {
+			continue
+		}
+
+		for _, marker := range markers {
+			start := strings.Index(template, marker)
+			if start < 0 {
+				continue
+			}
+
+			start += len(marker)
+
+			end := strings.IndexAny(template[start:], "/{#")
+			if end < 0 {
+				end = len(template) - start
+			}
+
+			return template[:start] + ref + template[start+end:], true
+		}
+	}
+
+	return template, false
+}
+
+// Ref returns most precise VCS reference known for dependency: revision,
+// tag (prefixed with directory for dependencies placed in repository
+// subdirectories, as Go does for modules) or branch.
+func (vd *VCSData) Ref() string {
+	switch {
+	case vd.Revision != "":
+		return vd.Revision
+	case vd.Tag != "" && vd.Dir != "":
+		return vd.Dir + "/" + vd.Tag
+	case vd.Tag != "":
+		return vd.Tag
+	}
+
+	return vd.Branch
+}
@@ -0,0 +1,112 @@
+package structs
+
+import (
+	// stdlib
+	"testing"
+)
+
+func TestVCSDataRef(t *testing.T) {
+	tests := []struct {
+		vcs VCSData
+		ref string
+	}{
+		{VCSData{Branch: "master"}, "master"},
+		{VCSData{Branch: "master", Tag: "v1.0.0"}, "v1.0.0"},
+		{VCSData{Tag: "v1.0.0", Dir: "sub"}, "sub/v1.0.0"},
+		{VCSData{Branch: "master", Revision: "abcdef123456", Tag: "v1.0.0"}, "abcdef123456"},
+		{VCSData{}, ""},
+	}
+
+	for _, test := range tests {
+		if ref := test.vcs.Ref(); ref != test.ref {
+			t.Errorf("Ref() for %+v = %q, want %q", test.vcs, ref, test.ref)
+		}
+	}
+}
+
+func TestFormatSourcePaths(t *testing.T) {
+	tests := []struct {
+		name         string
+		vcs          VCSData
+		dirTemplate  string
+		fileTemplate string
+	}{
+		{
+			name:         "generated for github",
+			vcs:          VCSData{VCS: "git", VCSPath: "https://github.com/user/repo.git", Tag: "v1.2.0"},
+			dirTemplate:  "https://github.com/user/repo/tree/v1.2.0{/dir}",
+			fileTemplate: "https://github.com/user/repo/blob/v1.2.0{/dir}/{file}#L{line}",
+		},
+		{
+			name:         "generated for github without .git suffix",
+			vcs:          VCSData{VCS: "git", VCSPath: "https://github.com/user/repo", Revision: "abcdef123456"},
+			dirTemplate:  "https://github.com/user/repo/tree/abcdef123456{/dir}",
+			fileTemplate: "https://github.com/user/repo/blob/abcdef123456{/dir}/{file}#L{line}",
+		},
+		{
+			name:         "generated for module in subdirectory",
+			vcs:          VCSData{VCS: "git", VCSPath: "https://github.com/user/repo.git", Tag: "v1.2.0", Dir: "sub"},
+			dirTemplate:  "https://github.com/user/repo/tree/sub/v1.2.0{/dir}",
+			fileTemplate: "https://github.com/user/repo/blob/sub/v1.2.0{/dir}/{file}#L{line}",
+		},
+		{
+			name: "github go-source",
+			vcs: VCSData{
+				VCS: "git", VCSPath: "https://github.com/golang/text", Tag: "v0.3.2",
+				SourceURLDirTemplate:  "https://github.com/golang/text/tree/master{/dir}",
+				SourceURLFileTemplate: "https://github.com/golang/text/blob/master{/dir}/{file}#L{line}",
+			},
+			dirTemplate:  "https://github.com/golang/text/tree/v0.3.2{/dir}",
+			fileTemplate: "https://github.com/golang/text/blob/v0.3.2{/dir}/{file}#L{line}",
+		},
+		{
+			name: "googlesource go-source",
+			vcs: VCSData{
+				VCS: "git", VCSPath: "https://go.googlesource.com/text", Revision: "abcdef123456",
+				SourceURLDirTemplate:  "https://go.googlesource.com/text/+/master{/dir}",
+				SourceURLFileTemplate: "https://go.googlesource.com/text/+/master{/dir}/{file}#{line}",
+			},
+			dirTemplate:  "https://go.googlesource.com/text/+/abcdef123456{/dir}",
+			fileTemplate: "https://go.googlesource.com/text/+/abcdef123456{/dir}/{file}#{line}",
+		},
+		{
+			name: "unknown go-source for github repository",
+			vcs: VCSData{
+				VCS: "git", VCSPath: "https://github.com/user/repo", Tag: "v1.0.0",
+				SourceURLDirTemplate:  "https://example.com/user/repo{/dir}",
+				SourceURLFileTemplate: "https://example.com/user/repo{/dir}/{file}",
+			},
+			dirTemplate:  "https://github.com/user/repo/tree/v1.0.0{/dir}",
+			fileTemplate: "https://github.com/user/repo/blob/v1.0.0{/dir}/{file}#L{line}",
+		},
+		{
+			name: "unknown go-source",
+			vcs: VCSData{
+				VCS: "git", VCSPath: "https://sources.example.com/user/repo.git", Tag: "v1.0.0",
+				SourceURLDirTemplate:  "https://sources.example.com/user/repo/src/branch/master{/dir}",
+				SourceURLFileTemplate: "https://sources.example.com/user/repo/src/branch/master{/dir}/{file}#L{line}",
+			},
+			dirTemplate:  "https://sources.example.com/user/repo/src/branch/master{/dir}",
+			fileTemplate: "https://sources.example.com/user/repo/src/branch/master{/dir}/{file}#L{line}",
+		},
+		{
+			name: "unknown reference",
+			vcs:  VCSData{VCS: "git", VCSPath: "https://github.com/user/repo.git"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vcs := test.vcs
+			vcs.FormatSourcePaths()
+
+			if vcs.SourceURLDirTemplate != test.dirTemplate {
+				t.Errorf("dir template = %q, want %q", vcs.SourceURLDirTemplate, test.dirTemplate)
+			}
+
+			if vcs.SourceURLFileTemplate != test.fileTemplate {
+				t.Errorf("file template = %q, want %q", vcs.SourceURLFileTemplate, test.fileTemplate)
+			}
+		})
+	}
+}
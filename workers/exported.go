@@ -0,0 +1,75 @@
+package workers
+
+import (
+	// stdlib
+	"runtime"
+	"strconv"
+	"sync"
+
+	// local
+	"go.dev.pztrn.name/glp/logger"
+)
+
+var (
+	slots chan struct{}
+
+	tasks      map[string]*task
+	tasksMutex sync.Mutex
+)
+
+// This structure represents single deduplicated piece of work.
+type task struct {
+	done   chan struct{}
+	result interface{}
+}
+
+// Initialize initializes package. Jobs is a maximum number of work
+// functions executed simultaneously, zero or negative value means
+// number of CPUs.
+func Initialize(jobs int) {
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+
+	logger.Debug("Initializing workers pool with " + strconv.Itoa(jobs) + " jobs...")
+
+	slots = make(chan struct{}, jobs)
+	tasks = make(map[string]*task)
+}
+
+// Do executes passed function in pool and returns it's result. Work is
+// deduplicated by key: if function for same key was already executed
+// or is executing right now - it won't be executed again and result of
+// first execution will be returned.
+// Do blocks until result is available, so it should be called from
+// separate goroutine for every piece of work. Passed function must not
+// call Do itself.
+func Do(key string, work func() interface{}) interface{} {
+	tasksMutex.Lock()
+	t, found := tasks[key]
+
+	if found {
+		tasksMutex.Unlock()
+		logger.Trace("Waiting for already scheduled work:", key)
+
+		<-t.done
+
+		return t.result
+	}
+
+	t = &task{done: make(chan struct{})}
+	tasks[key] = t
+	tasksMutex.Unlock()
+
+	slots <- struct{}{}
+
+	logger.Trace("Executing work:", key)
+
+	t.result = work()
+
+	<-slots
+
+	close(t.done)
+
+	return t.result
+}